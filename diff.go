@@ -0,0 +1,280 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// ChangeOp is the kind of a Change.
+type ChangeOp string
+
+const (
+	Added   ChangeOp = "added"
+	Removed ChangeOp = "removed"
+	Changed ChangeOp = "changed"
+)
+
+// Change is a difference between two values at Path. Old is nil for added
+// and New for removed locations.
+type Change struct {
+	Path string
+	Op   ChangeOp
+	Old  any
+	New  any
+}
+
+// Diff returns the changes from a to b, which have to be structs of the same
+// type. Structs are compared field by field, maps by key and slices by index
+// or, with MatchBy, by a key field. Values in interfaces and structs
+// without exported fields, like time.Time, are compared as a whole. Nil and
+// empty slices and maps are equal.
+//
+// The order is deterministic: fields in declaration order, map keys sorted.
+// The changes can be applied in order: elements removed from a slice are
+// reported from the last one and added elements are inserted at their index,
+// like Insert does. With MatchBy, unmatched elements are removed first and
+// elements that change their position are removed and added again.
+func Diff(a any, b any, opts ...Option) (result []Change, err error) {
+	defer catch(&err)
+
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+
+	if !utils.IsStruct(va) {
+		return nil, notStruct("diff", valueType(va))
+	}
+
+	if !utils.IsStruct(vb) {
+		return nil, notStruct("diff", valueType(vb))
+	}
+
+	ta := reflect.Indirect(va).Type()
+	tb := reflect.Indirect(vb).Type()
+
+	if ta != tb {
+		return nil, &PathError{Op: "diff", Index: -1, Type: tb, Err: kindf(ErrTypeMismatch, "can't compare %v with %v", ta, tb)}
+	}
+
+	d := &differ{
+		options: newOptions(opts...),
+		visited: map[[2]uintptr]bool{},
+	}
+
+	d.compare("", reflect.Indirect(va), reflect.Indirect(vb))
+
+	return d.changes, nil
+}
+
+type differ struct {
+	options
+
+	changes []Change
+	visited map[[2]uintptr]bool // pairs of compared pointers
+}
+
+func (d *differ) add(path Path, op ChangeOp, a reflect.Value, b reflect.Value) {
+	c := Change{Path: string(path), Op: op}
+
+	if a.IsValid() {
+		c.Old = a.Interface()
+	}
+
+	if b.IsValid() {
+		c.New = b.Interface()
+	}
+
+	d.changes = append(d.changes, c)
+}
+
+// compare adds the changes between the values a and b of the same type.
+func (d *differ) compare(path Path, a reflect.Value, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Pointer:
+		switch {
+		case a.IsNil() && b.IsNil():
+		case a.IsNil():
+			d.add(path, Added, reflect.Value{}, b)
+		case b.IsNil():
+			d.add(path, Removed, a, reflect.Value{})
+		default:
+			pair := [2]uintptr{a.Pointer(), b.Pointer()}
+			if d.visited[pair] {
+				return
+			}
+
+			d.visited[pair] = true
+			d.compare(path, a.Elem(), b.Elem())
+		}
+	case reflect.Struct:
+		if _, ok := record(a); !ok {
+			// structs like time.Time are compared as a whole
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				d.add(path, Changed, a, b)
+			}
+
+			return
+		}
+
+		t := a.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				d.compare(path.Field(t.Field(i).Name), a.Field(i), b.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if field, ok := d.keyField(path, a.Type()); ok {
+			d.compareByKey(path, a, b, field)
+			return
+		}
+
+		n := min(a.Len(), b.Len())
+
+		for i := 0; i < n; i++ {
+			d.compare(path.Index(i), a.Index(i), b.Index(i))
+		}
+
+		for i := n; i < b.Len(); i++ {
+			d.add(path.Index(i), Added, reflect.Value{}, b.Index(i))
+		}
+
+		for i := a.Len() - 1; i >= n; i-- {
+			d.add(path.Index(i), Removed, a.Index(i), reflect.Value{})
+		}
+	case reflect.Map:
+		keys := sortedKeys(a)
+
+		for _, k := range keys {
+			if vb := b.MapIndex(k); vb.IsValid() {
+				d.compare(path.Key(k.Interface()), a.MapIndex(k), vb)
+			} else {
+				d.add(path.Key(k.Interface()), Removed, a.MapIndex(k), reflect.Value{})
+			}
+		}
+
+		for _, k := range sortedKeys(b) {
+			if !a.MapIndex(k).IsValid() {
+				d.add(path.Key(k.Interface()), Added, reflect.Value{}, b.MapIndex(k))
+			}
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(path, Changed, a, b)
+		}
+	}
+}
+
+// keyField returns the key field set by MatchBy for the slice type t at path.
+func (o *options) keyField(path Path, t reflect.Type) (string, bool) {
+	if len(o.keys) == 0 || t.Kind() != reflect.Slice {
+		return "", false
+	}
+
+	e := t.Elem()
+	for e.Kind() == reflect.Pointer {
+		e = e.Elem()
+	}
+
+	if e.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	elements := normalize(string(path))
+
+	for _, k := range o.keys {
+		if _, ok := e.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, k.field)
+		}); ok && matches(k.pattern, elements) {
+			return k.field, true
+		}
+	}
+
+	return "", false
+}
+
+// compareByKey compares the elements of the slices a and b with the same
+// value of field.
+func (d *differ) compareByKey(path Path, a reflect.Value, b reflect.Value, field string) {
+	index := map[any]int{}
+
+	for i := 0; i < a.Len(); i++ {
+		if k, ok := keyOf(a.Index(i), field); ok {
+			if _, dup := index[k]; !dup {
+				index[k] = i
+			}
+		}
+	}
+
+	match := make([]int, b.Len())
+	matched := map[int]bool{}
+
+	for i := 0; i < b.Len(); i++ {
+		k, ok := keyOf(b.Index(i), field)
+
+		j, found := index[k]
+		if ok && found && !matched[j] {
+			matched[j] = true
+			match[i] = j
+		} else {
+			match[i] = -1
+		}
+	}
+
+	// remove unmatched elements first, from the last one
+	var current []int
+
+	for i := a.Len() - 1; i >= 0; i-- {
+		if matched[i] {
+			current = append([]int{i}, current...)
+		} else {
+			d.add(path.Index(i), Removed, a.Index(i), reflect.Value{})
+		}
+	}
+
+	// the elements before i are in place, so every index is valid at the
+	// time its change is applied
+	for i, j := range match {
+		if j < 0 {
+			d.add(path.Index(i), Added, reflect.Value{}, b.Index(i))
+			current = slices.Insert(current, i, -1)
+
+			continue
+		}
+
+		p := slices.Index(current, j)
+		if p == i {
+			d.compare(path.Index(i), a.Index(j), b.Index(i))
+			continue
+		}
+
+		d.add(path.Index(p), Removed, a.Index(j), reflect.Value{})
+		d.add(path.Index(i), Added, reflect.Value{}, b.Index(i))
+		current = slices.Insert(slices.Delete(current, p, p+1), i, j)
+	}
+}
+
+// keyOf returns the value of the key field of the struct element v.
+func keyOf(v reflect.Value, field string) (any, bool) {
+	v = reflect.Indirect(v)
+	if !v.IsValid() {
+		return nil, false
+	}
+
+	f := v.FieldByNameFunc(func(name string) bool {
+		return strings.EqualFold(name, field)
+	})
+
+	if !f.IsValid() || !f.Type().Comparable() {
+		return nil, false
+	}
+
+	return f.Interface(), true
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type diffServer struct {
+	Name string
+	Port int
+}
+
+type diffObj struct {
+	Name    string
+	Server  *diffServer
+	Servers []diffServer
+	Tags    []string
+	Labels  map[string]string
+	Any     any
+	At      time.Time
+	Next    *diffObj
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *diffObj
+		b    *diffObj
+		opts []lookup.Option
+		want []lookup.Change
+	}{
+		{"equal", &diffObj{Name: "x", Tags: []string{}}, &diffObj{Name: "x"}, nil, nil},
+		{"field", &diffObj{Name: "x"}, &diffObj{Name: "y"}, nil, []lookup.Change{
+			{Path: "Name", Op: lookup.Changed, Old: "x", New: "y"},
+		}},
+		{"time", &diffObj{At: time.Unix(0, 0)}, &diffObj{At: time.Unix(100, 0)}, nil, []lookup.Change{
+			{Path: "At", Op: lookup.Changed, Old: time.Unix(0, 0), New: time.Unix(100, 0)},
+		}},
+		{"equal time", &diffObj{At: time.Unix(100, 0)}, &diffObj{At: time.Unix(100, 0)}, nil, nil},
+		{"pointer", &diffObj{}, &diffObj{Server: &diffServer{Port: 80}}, nil, []lookup.Change{
+			{Path: "Server", Op: lookup.Added, New: &diffServer{Port: 80}},
+		}},
+		{"nested", &diffObj{Server: &diffServer{Port: 80}}, &diffObj{Server: &diffServer{Port: 81}}, nil, []lookup.Change{
+			{Path: "Server.Port", Op: lookup.Changed, Old: 80, New: 81},
+		}},
+		{"slice", &diffObj{Tags: []string{"a", "b", "c"}}, &diffObj{Tags: []string{"x"}}, nil, []lookup.Change{
+			{Path: "Tags[0]", Op: lookup.Changed, Old: "a", New: "x"},
+			{Path: "Tags[2]", Op: lookup.Removed, Old: "c"},
+			{Path: "Tags[1]", Op: lookup.Removed, Old: "b"},
+		}},
+		{"map", &diffObj{Labels: map[string]string{"a": "1", "b": "2"}}, &diffObj{Labels: map[string]string{"b": "3", "c": "4"}}, nil, []lookup.Change{
+			{Path: `Labels["a"]`, Op: lookup.Removed, Old: "1"},
+			{Path: `Labels["b"]`, Op: lookup.Changed, Old: "2", New: "3"},
+			{Path: `Labels["c"]`, Op: lookup.Added, New: "4"},
+		}},
+		{"interface", &diffObj{Any: []int{1}}, &diffObj{Any: []int{2}}, nil, []lookup.Change{
+			{Path: "Any", Op: lookup.Changed, Old: []int{1}, New: []int{2}},
+		}},
+		{
+			"by index",
+			&diffObj{Servers: []diffServer{{"a", 1}, {"b", 2}}},
+			&diffObj{Servers: []diffServer{{"b", 2}}},
+			nil,
+			[]lookup.Change{
+				{Path: "Servers[0].Name", Op: lookup.Changed, Old: "a", New: "b"},
+				{Path: "Servers[0].Port", Op: lookup.Changed, Old: 1, New: 2},
+				{Path: "Servers[1]", Op: lookup.Removed, Old: diffServer{"b", 2}},
+			},
+		},
+		{
+			"by key",
+			&diffObj{Servers: []diffServer{{"a", 1}, {"b", 2}}},
+			&diffObj{Servers: []diffServer{{"b", 3}, {"c", 4}}},
+			[]lookup.Option{lookup.MatchBy("Servers", "name")},
+			[]lookup.Change{
+				{Path: "Servers[0]", Op: lookup.Removed, Old: diffServer{"a", 1}},
+				{Path: "Servers[0].Port", Op: lookup.Changed, Old: 2, New: 3},
+				{Path: "Servers[1]", Op: lookup.Added, New: diffServer{"c", 4}},
+			},
+		},
+		{
+			"by key reordered",
+			&diffObj{Servers: []diffServer{{"a", 1}, {"b", 2}}},
+			&diffObj{Servers: []diffServer{{"b", 2}, {"a", 1}}},
+			[]lookup.Option{lookup.MatchBy("Servers", "name")},
+			[]lookup.Change{
+				{Path: "Servers[1]", Op: lookup.Removed, Old: diffServer{"b", 2}},
+				{Path: "Servers[0]", Op: lookup.Added, New: diffServer{"b", 2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := lookup.Diff(tt.a, tt.b, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, changes)
+		})
+	}
+}
+
+func TestDiff_Apply(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *diffObj
+		b    *diffObj
+		opts []lookup.Option
+	}{
+		{
+			"by index",
+			&diffObj{Name: "x", Tags: []string{"a", "b"}, Labels: map[string]string{"a": "1"}},
+			&diffObj{Name: "y", Tags: []string{"c"}, Labels: map[string]string{"b": "2"}, Server: &diffServer{Port: 1}},
+			nil,
+		},
+		{
+			"by key",
+			&diffObj{Servers: []diffServer{{"x", 1}, {"y", 2}, {"z", 3}}},
+			&diffObj{Servers: []diffServer{{"w", 0}, {"z", 4}, {"y", 2}}},
+			[]lookup.Option{lookup.MatchBy("Servers", "name")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := lookup.Diff(tt.a, tt.b, tt.opts...)
+			require.NoError(t, err)
+
+			for _, c := range changes {
+				switch c.Op {
+				case lookup.Removed:
+					_, err = lookup.Delete(tt.a, c.Path)
+				case lookup.Added:
+					_, err = lookup.Insert(tt.a, c.Path, c.New)
+					if errors.Is(err, lookup.ErrNotSlice) {
+						_, err = lookup.Set(tt.a, c.Path, c.New)
+					}
+				default:
+					_, err = lookup.Set(tt.a, c.Path, c.New)
+				}
+
+				require.NoError(t, err, c.Path)
+			}
+
+			assert.Equal(t, tt.b, tt.a)
+		})
+	}
+}
+
+func TestDiff_Cycle(t *testing.T) {
+	a := &diffObj{Name: "a"}
+	a.Next = a
+
+	b := &diffObj{Name: "b"}
+	b.Next = b
+
+	changes, err := lookup.Diff(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, []lookup.Change{
+		{Path: "Name", Op: lookup.Changed, Old: "a", New: "b"},
+		{Path: "Next.Name", Op: lookup.Changed, Old: "a", New: "b"},
+	}, changes)
+}
+
+func TestDiff_Error(t *testing.T) {
+	_, err := lookup.Diff(&diffObj{}, &diffServer{})
+	assert.ErrorIs(t, err, lookup.ErrTypeMismatch)
+
+	_, err = lookup.Diff(1, 2)
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+}
@@ -5,18 +5,189 @@
 
 package lookup
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 var (
 	ErrUnsupportedMap = errors.New("only maps with string keys are supported")
 	ErrNotMap         = errors.New("field is not a map")
 	ErrNotSlice       = errors.New("field is not an array or slice")
+	ErrForbidden      = errors.New("access forbidden")
+	ErrReadOnly       = errors.New("field is read only")
+	ErrImmutable      = errors.New("field is immutable")
+	ErrNotStruct      = errors.New("not a struct")
+	ErrNotAddressable = errors.New("not addressable")
+	ErrNotExpandable  = errors.New("not expandable")
+	ErrTypeMismatch   = errors.New("type mismatch")
+	ErrUnexported     = errors.New("not exported")
+	ErrTestFailed     = errors.New("test failed")
+)
+
+// PathError records the operation and location of a failure. Path is the
+// resolved part of the path up to and including Segment, which was applied
+// to a value of Type. Index is the position of Segment in the path or -1 if
+// the operation failed before the first segment.
+type PathError struct {
+	Op      string
+	Path    string
+	Segment string
+	Index   int
+	Type    reflect.Type
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	if e.Path == "" {
+		return e.Op + " " + e.Err.Error()
+	}
+
+	return e.Op + " " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// Reasons of an OverflowError.
+const (
+	Overflow   = "overflow"
+	SignLoss   = "sign loss"
+	Truncation = "truncation"
 )
 
+// OverflowError is returned if a number doesn't fit into the numeric type of
+// a location. Reason is Overflow, SignLoss or Truncation. Err is the parse
+// error for numbers given as strings.
+type OverflowError struct {
+	Value  any
+	Type   reflect.Type
+	Reason string
+	Err    error
+}
+
+func (e *OverflowError) Error() string {
+	msg := fmt.Sprintf("%v: %v doesn't fit into %v", e.Reason, e.Value, e.Type)
+
+	if e.Err != nil {
+		msg += " (" + e.Err.Error() + ")"
+	}
+
+	return msg
+}
+
+func (e *OverflowError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is returned instead of a panic during an operation, e.g. raised
+// by reflect or a parser hook.
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// catch stores a recovered panic as PanicError in err. It has to be deferred
+// directly.
+func catch(err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{r}
+	}
+}
+
+// kindError keeps the message of err and matches kind with errors.Is.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func kindf(kind error, format string, args ...any) error {
+	return &kindError{kind, fmt.Errorf(format, args...)}
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func notStruct(op string, t reflect.Type) error {
+	return &PathError{Op: op, Index: -1, Type: t, Err: kindf(ErrNotStruct, "supports only structs")}
+}
+
+func notPointer(op string, t reflect.Type) error {
+	return &PathError{Op: op, Index: -1, Type: t, Err: kindf(ErrNotAddressable, "supports only struct pointers")}
+}
+
+// NotFoundError is returned for a field that doesn't exist. Suggestions
+// contains similar field names.
 type NotFoundError struct {
-	Name string
+	Name        string
+	Suggestions []string
 }
 
 func (e *NotFoundError) Error() string {
-	return "field not found: " + e.Name
+	msg := "field not found: " + e.Name
+
+	if len(e.Suggestions) > 0 {
+		msg += " (did you mean " + strings.Join(e.Suggestions, ", ") + "?)"
+	}
+
+	return msg
+}
+
+// IndexOutOfRangeError is returned in strict mode for an index beyond the end
+// of a slice.
+type IndexOutOfRangeError struct {
+	Path  string
+	Index int
+	Len   int
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("index out of range: %v (length %v)", e.Path, e.Len)
+}
+
+// MissingKeyError is returned in strict mode for a map key that doesn't exist.
+type MissingKeyError struct {
+	Path string
+	Key  string
+}
+
+func (e *MissingKeyError) Error() string {
+	return "map key not found: " + e.Path
+}
+
+// MissingValueError is returned in strict mode for a nil value that would have
+// to be created to continue the path.
+type MissingValueError struct {
+	Path string
+}
+
+func (e *MissingValueError) Error() string {
+	return "value is nil: " + e.Path
+}
+
+// LimitError is returned if an operation exceeds one of its Limits.
+type LimitError struct {
+	Limit string
+	Max   int
+	Value int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v limit exceeded: %v > %v", e.Limit, e.Value, e.Max)
 }
@@ -0,0 +1,127 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestPathError(t *testing.T) {
+	type item struct {
+		Port uint16
+	}
+
+	type obj struct {
+		Items   []item
+		Array   [1]item
+		Map     map[int]string
+		Text    string
+		private string
+	}
+
+	tests := []struct {
+		name      string
+		op        func(o *obj) error
+		kind      error
+		operation string
+		path      string
+		segment   string
+		index     int
+		typ       reflect.Type
+	}{
+		{"not a struct", func(o *obj) error {
+			_, err := lookup.Get(o, "Text.Name")
+			return err
+		}, lookup.ErrNotStruct, "get", "Text.Name", "Name", 1, reflect.TypeFor[string]()},
+		{"not expandable", func(o *obj) error {
+			_, err := lookup.Set(o, "array[1].port", 1)
+			return err
+		}, lookup.ErrNotExpandable, "set", "Array[1]", "[1]", 1, reflect.TypeFor[[1]item]()},
+		{"type mismatch", func(o *obj) error {
+			_, err := lookup.Set(o, "Items[0].Port", []int{1})
+			return err
+		}, lookup.ErrTypeMismatch, "set", "Items[0].Port", "Port", 2, reflect.TypeFor[item]()},
+		{"unparsable value", func(o *obj) error {
+			_, err := lookup.Set(o, "Items[0].Port", "x")
+			return err
+		}, lookup.ErrTypeMismatch, "set", "Items[0].Port", "Port", 2, reflect.TypeFor[item]()},
+		{"unparsable key", func(o *obj) error {
+			_, err := lookup.Get(o, "Map[x]")
+			return err
+		}, lookup.ErrTypeMismatch, "get", "Map[x]", "[x]", 1, reflect.TypeFor[map[int]string]()},
+		{"unexported", func(o *obj) error {
+			_, err := lookup.Get(o, "private")
+			return err
+		}, lookup.ErrUnexported, "get", "private", "private", 0, reflect.TypeFor[*obj]()},
+		{"not a slice", func(o *obj) error {
+			_, err := lookup.Insert(o, "Text", "x")
+			return err
+		}, lookup.ErrNotSlice, "insert", "Text", "Text", 0, reflect.TypeFor[*obj]()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &obj{Items: []item{{}}}
+
+			err := tt.op(o)
+			assert.ErrorIs(t, err, tt.kind)
+
+			var e *lookup.PathError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, tt.operation, e.Op)
+				assert.Equal(t, tt.path, e.Path)
+				assert.Equal(t, tt.segment, e.Segment)
+				assert.Equal(t, tt.index, e.Index)
+				assert.Equal(t, tt.typ, e.Type)
+			}
+		})
+	}
+
+	t.Run("cause", func(t *testing.T) {
+		_, err := lookup.Set(&obj{}, "Items[0].Port", "x")
+
+		var e *strconv.NumError
+		assert.ErrorAs(t, err, &e)
+		assert.ErrorContains(t, err, "set Items[0].Port: ")
+	})
+
+	t.Run("object", func(t *testing.T) {
+		_, err := lookup.Set(obj{}, "Text", "x")
+		assert.ErrorIs(t, err, lookup.ErrNotAddressable)
+		assert.EqualError(t, err, "set supports only struct pointers")
+
+		_, err = lookup.Get(1, "Text")
+		assert.ErrorIs(t, err, lookup.ErrNotStruct)
+
+		var e *lookup.PathError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Equal(t, -1, e.Index)
+			assert.Equal(t, reflect.TypeFor[int](), e.Type)
+		}
+	})
+}
+
+func TestPanicError(t *testing.T) {
+	_, err := lookup.Parse("1", nil)
+
+	var e *lookup.PanicError
+	assert.ErrorAs(t, err, &e)
+
+	hook := lookup.NewParserHookFor[int](func(txt string) (any, error) {
+		panic("broken hook")
+	})
+
+	_, err = lookup.Parse("1", reflect.TypeFor[int](), hook)
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, "broken hook", e.Value)
+		assert.EqualError(t, err, "panic: broken hook")
+	}
+}
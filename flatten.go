@@ -0,0 +1,87 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// Flatten returns the leaves of the struct obj keyed by their paths, e.g.
+// `Address.City` or `Tags[0]`. With Containers the map includes structs,
+// slices, arrays and maps as well, with JSONPointer the keys are JSON
+// Pointers like `/Address/City`.
+func Flatten(obj any, opts ...Option) (result map[string]any, err error) {
+	defer catch(&err)
+
+	v := reflect.ValueOf(obj)
+
+	if !utils.IsStruct(v) {
+		return nil, notStruct("flatten", valueType(v))
+	}
+
+	o := newOptions(opts...)
+	result = map[string]any{}
+
+	fn := func(path Path, v reflect.Value, field *reflect.StructField) error {
+		key := string(path)
+		if o.pointers {
+			key = toPointer(key)
+		}
+
+		result[key] = v.Interface()
+		return nil
+	}
+
+	err = newVisitor(fn, !o.containers).run(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Unflatten sets a copy of every entry of values in the struct pointer target
+// like Set, so strings are parsed and missing locations created. Parents are
+// set before their children. The errors of all failed entries are joined.
+func Unflatten(values map[string]any, target any, opts ...Option) error {
+	v := reflect.ValueOf(target)
+
+	if !utils.IsStruct(v) {
+		return notStruct("unflatten", valueType(v))
+	}
+
+	if !utils.IsPointer(v) {
+		return notPointer("unflatten", valueType(v))
+	}
+
+	o := newOptions(opts...)
+
+	var errs []error
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		path := key
+		if o.pointers {
+			path = string(fromPointer(v.Type(), key))
+		}
+
+		// don't share containers with the source of the values
+		val, err := clone(values[key])
+		if err == nil {
+			_, err = Set(target, path, val, opts...)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type flatAddress struct {
+	City string
+	Zip  int
+}
+
+type flatObj struct {
+	Name    string
+	Address *flatAddress
+	Tags    []string
+	Labels  map[string]string `json:"labels"`
+	Ports   map[int]bool
+}
+
+func newFlatObj() *flatObj {
+	return &flatObj{
+		Name:    "x",
+		Address: &flatAddress{City: "Berlin", Zip: 10115},
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"app/name": "web"},
+		Ports:   map[int]bool{80: true},
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	o := newFlatObj()
+
+	result, err := lookup.Flatten(o)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"Name":               "x",
+		"Address.City":       "Berlin",
+		"Address.Zip":        10115,
+		"Tags[0]":            "a",
+		"Tags[1]":            "b",
+		`Labels["app/name"]`: "web",
+		"Ports[80]":          true,
+	}, result)
+
+	result, err = lookup.Flatten(o, lookup.JSONPointer())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"/Name":             "x",
+		"/Address/City":     "Berlin",
+		"/Address/Zip":      10115,
+		"/Tags/0":           "a",
+		"/Tags/1":           "b",
+		"/Labels/app~1name": "web",
+		"/Ports/80":         true,
+	}, result)
+
+	result, err = lookup.Flatten(o, lookup.Containers())
+	require.NoError(t, err)
+	assert.Len(t, result, 11)
+	assert.Equal(t, o.Tags, result["Tags"])
+	assert.Equal(t, o.Address, result["Address"])
+
+	_, err = lookup.Flatten(1)
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+}
+
+func TestUnflatten(t *testing.T) {
+	for name, opts := range map[string][]lookup.Option{
+		"paths":                    nil,
+		"pointers":                 {lookup.JSONPointer()},
+		"containers":               {lookup.Containers()},
+		"pointers with containers": {lookup.JSONPointer(), lookup.Containers()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			o := newFlatObj()
+
+			values, err := lookup.Flatten(o, opts...)
+			require.NoError(t, err)
+
+			result := &flatObj{}
+			err = lookup.Unflatten(values, result, opts...)
+			if assert.NoError(t, err) {
+				assert.Equal(t, o, result)
+			}
+
+			result.Address.City = "Hamburg"
+			result.Tags[0] = "c"
+			assert.Equal(t, newFlatObj(), o)
+		})
+	}
+
+	t.Run("parse", func(t *testing.T) {
+		result := &flatObj{}
+
+		err := lookup.Unflatten(map[string]any{
+			"Address.Zip": "12345",
+			"Ports[443]":  "false",
+			"Tags[1]":     "b",
+		}, result)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, &flatObj{
+				Address: &flatAddress{Zip: 12345},
+				Tags:    []string{"", "b"},
+				Ports:   map[int]bool{443: false},
+			}, result)
+		}
+	})
+
+	t.Run("json names", func(t *testing.T) {
+		result := &flatObj{}
+
+		err := lookup.Unflatten(map[string]any{
+			"/labels/a~0b": "c",
+			"/tags/-":      "x",
+		}, result, lookup.JSONPointer())
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, &flatObj{
+				Tags:   []string{"x"},
+				Labels: map[string]string{"a~b": "c"},
+			}, result)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		result := &flatObj{}
+
+		err := lookup.Unflatten(map[string]any{
+			"Name":        "x",
+			"Address.Zip": "x",
+			"Unknown":     1,
+		}, result)
+
+		var nf *lookup.NotFoundError
+		assert.ErrorAs(t, err, &nf)
+		assert.ErrorIs(t, err, lookup.ErrTypeMismatch)
+		assert.Equal(t, "x", result.Name)
+
+		err = lookup.Unflatten(nil, flatObj{})
+		assert.ErrorIs(t, err, lookup.ErrNotAddressable)
+	})
+}
+
+func TestUnflatten_Cycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	type obj struct {
+		N *node
+	}
+
+	n := &node{Name: "a"}
+	n.Next = n
+
+	o := &obj{}
+
+	err := lookup.Unflatten(map[string]any{"N": n}, o)
+	require.NoError(t, err)
+
+	assert.NotSame(t, n, o.N)
+	assert.Same(t, o.N, o.N.Next)
+	assert.Equal(t, "a", o.N.Name)
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zauberhaus/lookup"
+)
+
+var paths = []string{
+	"Name",
+	"Items[0]",
+	"Items[]",
+	"Items[-1]",
+	`Map["a"]`,
+	"Map['a'].Name",
+	"Nested.Items[2][1]",
+	"Pointer.Next.Next.Name",
+	"a[[0]]",
+	`a["]`,
+	"..",
+	"[",
+	"]",
+	"",
+}
+
+var fields = []reflect.Type{
+	reflect.TypeFor[string](),
+	reflect.TypeFor[int](),
+	reflect.TypeFor[uint8](),
+	reflect.TypeFor[float32](),
+	reflect.TypeFor[bool](),
+	reflect.TypeFor[time.Duration](),
+	reflect.TypeFor[*string](),
+	reflect.TypeFor[[]string](),
+	reflect.TypeFor[[2]int](),
+	reflect.TypeFor[map[string]int](),
+	reflect.TypeFor[map[int]string](),
+	reflect.TypeFor[map[string]any](),
+	reflect.TypeFor[any](),
+	reflect.TypeFor[error](),
+	reflect.TypeFor[chan int](),
+	reflect.TypeFor[func()](),
+}
+
+// generate builds a struct type from shape. Each byte adds a field of one of
+// the basic types, a container of the previous struct or a nested struct.
+func generate(shape []byte) reflect.Type {
+	t := reflect.StructOf([]reflect.StructField{{Name: "Name", Type: reflect.TypeFor[string]()}})
+
+	var list []reflect.StructField
+
+	for i, b := range shape {
+		if i >= 16 {
+			break
+		}
+
+		var ft reflect.Type
+
+		switch n := int(b) % (len(fields) + 5); n {
+		case len(fields):
+			ft = t
+		case len(fields) + 1:
+			ft = reflect.PointerTo(t)
+		case len(fields) + 2:
+			ft = reflect.SliceOf(t)
+		case len(fields) + 3:
+			ft = reflect.MapOf(reflect.TypeFor[string](), t)
+		case len(fields) + 4:
+			ft = reflect.SliceOf(reflect.PointerTo(t))
+		default:
+			ft = fields[n]
+		}
+
+		name := fmt.Sprintf("F%d", i)
+		if b&0x80 != 0 {
+			name = []string{"Name", "Items", "Map", "Nested", "Pointer"}[i%5]
+		}
+
+		list = append(list, reflect.StructField{Name: name + strings.Repeat("X", i), Type: ft})
+
+		if b%7 == 0 {
+			t = reflect.StructOf(list)
+			list = nil
+		}
+	}
+
+	if len(list) > 0 {
+		t = reflect.StructOf(list)
+	}
+
+	return t
+}
+
+func FuzzSplit(f *testing.F) {
+	for _, p := range paths {
+		f.Add(p)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		for _, part := range lookup.SplitPath(path) {
+			if part == "" {
+				t.Errorf("empty segment in %q", path)
+			}
+		}
+	})
+}
+
+func FuzzSplitSep(f *testing.F) {
+	for _, p := range paths {
+		f.Add(p, '.')
+	}
+
+	f.Add("a=1,b={c,d},e='f,g'", ',')
+
+	f.Fuzz(func(t *testing.T, txt string, sep rune) {
+		parts := lookup.Split(txt, sep)
+
+		if len(parts) == 0 {
+			t.Errorf("no parts for %q", txt)
+		}
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"", "1", "-1", "1.5", "true", "1s", "a,b", "a=1,b=2", "[1,2]", `{"Name":"x"}`, "\xff"} {
+		for i := range fields {
+			f.Add(s, uint8(i))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, txt string, n uint8) {
+		_, _ = lookup.Parse(txt, fields[int(n)%len(fields)])
+	})
+}
+
+func FuzzGetSet(f *testing.F) {
+	for i, p := range paths {
+		f.Add([]byte{byte(i), 0x82, 0x87, 0x90, byte(i * 31)}, p, "1")
+	}
+
+	// typed nil pointers into an int, a uint8 and a struct field
+	f.Add([]byte{1, 2, 16}, "F0", "1")
+	f.Add([]byte{1, 2, 16}, "F1X", "1")
+	f.Add([]byte{1, 2, 16}, "F2XX", "1")
+
+	f.Fuzz(func(t *testing.T, shape []byte, path string, value string) {
+		typ := generate(shape)
+
+		check := func(op string, err error) {
+			if err == nil {
+				return
+			}
+
+			var e *lookup.PathError
+			if !errors.As(err, &e) {
+				t.Errorf("%v %q on %v: %T isn't a PathError: %v", op, path, typ, err, err)
+			}
+
+			var p *lookup.PanicError
+			if errors.As(err, &p) {
+				t.Errorf("%v %q on %v: recovered panic: %v", op, path, typ, err)
+			}
+		}
+
+		limits := lookup.WithLimits(lookup.Limits{MaxGrowth: 100, MaxAllocations: 100})
+
+		obj := reflect.New(typ).Interface()
+
+		_, err := lookup.Get(obj, path, limits)
+		check("get", err)
+
+		_, err = lookup.Exists(obj, path, limits)
+		check("exists", err)
+
+		_, err = lookup.Set(obj, path, value, limits)
+		check("set", err)
+
+		for _, v := range []any{(*int)(nil), (*string)(nil), (*time.Duration)(nil)} {
+			_, err = lookup.Set(obj, path, v, limits)
+			check("set nil", err)
+		}
+
+		_, err = lookup.Create(obj, path, limits)
+		check("create", err)
+
+		_, err = lookup.Insert(obj, path, value, limits)
+		check("insert", err)
+
+		_, err = lookup.Delete(obj, path, limits)
+		check("delete", err)
+	})
+}
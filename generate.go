@@ -0,0 +1,279 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// CreateJSONPatch returns the JSON Patch document (RFC 6902) that turns old
+// into new, which have to be structs of the same type. The operations are
+// the changes reported by Diff with struct fields named by their `json` tag.
+// Fields ignored by JSON are skipped and the fields of embedded structs
+// without a `json` name are inlined, like encoding/json does.
+func CreateJSONPatch(old any, new any) ([]byte, error) {
+	changes, err := Diff(old, new)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(old)
+	ops := []operation{}
+
+	for _, c := range changes {
+		steps, ok := jsonSteps(t, c.Path)
+		if !ok {
+			continue
+		}
+
+		if steps[len(steps)-1].inline {
+			// the fields of an embedded struct pointer are added or removed
+			members, err := inlineMembers(c)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, m := range members {
+				op := operation{Op: "add", Path: jsonPointer(steps) + "/" + pointerEscaper.Replace(m.name), Value: m.value}
+				if m.value == nil {
+					op.Op = "remove"
+				}
+
+				ops = append(ops, op)
+			}
+
+			continue
+		}
+
+		op := operation{Path: jsonPointer(steps)}
+
+		switch {
+		case c.Op == Added:
+			op.Op = "add"
+		case c.Op == Removed && !steps[len(steps)-1].field:
+			op.Op = "remove"
+		default:
+			// fields are null instead of missing
+			op.Op = "replace"
+		}
+
+		if op.Op != "remove" {
+			op.Value, err = json.Marshal(c.New)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ops = append(ops, op)
+	}
+
+	return json.Marshal(ops)
+}
+
+// CreateMergePatch returns the JSON Merge Patch document (RFC 7386) that
+// turns old into new, which have to be structs of the same type. A change
+// of a slice or array element replaces the whole list, removed map entries
+// and nil pointers are null.
+func CreateMergePatch(old any, new any) ([]byte, error) {
+	changes, err := Diff(old, new)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(old)
+	doc := map[string]any{}
+
+	for _, c := range changes {
+		steps, ok := jsonSteps(t, c.Path)
+		if !ok {
+			continue
+		}
+
+		value := c.New
+
+		for i, s := range steps {
+			if s.list {
+				steps = steps[:i]
+
+				value, err = Get(new, string(steps[i-1].path))
+				if err != nil {
+					return nil, err
+				}
+
+				break
+			}
+		}
+
+		parent := doc
+
+		for _, s := range steps[:len(steps)-1] {
+			if s.inline {
+				continue
+			}
+
+			next, ok := parent[s.token].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				parent[s.token] = next
+			}
+
+			parent = next
+		}
+
+		if !steps[len(steps)-1].inline {
+			parent[steps[len(steps)-1].token] = value
+			continue
+		}
+
+		members, err := inlineMembers(c)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range members {
+			parent[m.name] = m.value
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// step is a field, element or entry of a path with its JSON name.
+type step struct {
+	token  string // name in JSON
+	path   Path   // path up to and including the step
+	field  bool   // struct field
+	list   bool   // element of a slice or array
+	inline bool   // embedded struct without a name in JSON
+}
+
+// jsonSteps resolves the path reported by Diff on the type t. It returns
+// false if the path contains a field ignored by JSON.
+func jsonSteps(t reflect.Type, path string) ([]step, bool) {
+	var (
+		steps []step
+		p     Path
+	)
+
+	for _, element := range normalize(path) {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		s := step{}
+
+		if key, ok := strings.CutPrefix(element, "["); ok {
+			key = strings.TrimSuffix(key, "]")
+
+			switch t.Kind() {
+			case reflect.Map:
+				p = p.Key(key)
+			default:
+				p += "[" + Path(key) + "]"
+				s.list = true
+			}
+
+			s.token = key
+			t = t.Elem()
+		} else {
+			field, ok := t.FieldByNameFunc(func(name string) bool {
+				return strings.EqualFold(name, element)
+			})
+			if !ok {
+				return nil, false
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return nil, false
+			}
+
+			if name == "" && !inlined(field) {
+				name = field.Name
+			}
+
+			p = p.Field(field.Name)
+			s.token = name
+			s.field = true
+			s.inline = inlined(field)
+			t = field.Type
+		}
+
+		s.path = p
+		steps = append(steps, s)
+	}
+
+	return steps, len(steps) > 0
+}
+
+// jsonPointer returns the JSON Pointer of the steps.
+func jsonPointer(steps []step) string {
+	var sb strings.Builder
+
+	for _, s := range steps {
+		if s.inline {
+			continue
+		}
+
+		sb.WriteString("/")
+		sb.WriteString(pointerEscaper.Replace(s.token))
+	}
+
+	return sb.String()
+}
+
+// member is a field of a JSON object, without a value if it was removed.
+type member struct {
+	name  string
+	value json.RawMessage
+}
+
+// inlineMembers returns the fields of an embedded struct pointer that the
+// change c adds, replaces or removes, sorted by name.
+func inlineMembers(c Change) ([]member, error) {
+	old, err := jsonObject(c.Old)
+	if err != nil {
+		return nil, err
+	}
+
+	new, err := jsonObject(c.New)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []member
+
+	for name, value := range new {
+		result = append(result, member{name, value})
+	}
+
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			result = append(result, member{name: name})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b member) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return result, nil
+}
+
+// jsonObject returns the fields of v encoded as JSON object, none for nil.
+func jsonObject(v any) (map[string]json.RawMessage, error) {
+	var result map[string]json.RawMessage
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, json.Unmarshal(raw, &result)
+}
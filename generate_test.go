@@ -0,0 +1,178 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type generateObj struct {
+	Name    string            `json:"name"`
+	Timeout time.Duration     `json:"timeout"`
+	Updated time.Time         `json:"updated"`
+	Server  *patchServer      `json:"server,omitempty"`
+	Servers []patchServer     `json:"servers"`
+	Labels  map[string]string `json:"labels"`
+	Ports   map[int]bool      `json:"ports"`
+	Secret  string            `json:"-"`
+}
+
+func newGenerateObjs() (*generateObj, *generateObj) {
+	old := &generateObj{
+		Name:    "x",
+		Timeout: time.Second,
+		Updated: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Server:  &patchServer{"h", 1},
+		Servers: []patchServer{{"a", 1}, {"b", 2}, {"c", 3}},
+		Labels:  map[string]string{"app/name": "web", "tier": "db"},
+		Ports:   map[int]bool{80: true},
+	}
+
+	new := &generateObj{
+		Name:    "y",
+		Timeout: time.Second,
+		Updated: time.Date(2026, 2, 1, 12, 30, 0, 0, time.UTC),
+		Servers: []patchServer{{"a", 8080}},
+		Labels:  map[string]string{"app/name": "api", "env": "prod", "Zone": "eu"},
+		Ports:   map[int]bool{80: true, 443: true},
+		Secret:  "s",
+	}
+
+	return old, new
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	old, new := newGenerateObjs()
+
+	patch, err := lookup.CreateJSONPatch(old, new)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op": "replace", "path": "/name", "value": "y"},
+		{"op": "replace", "path": "/updated", "value": "2026-02-01T12:30:00Z"},
+		{"op": "replace", "path": "/server", "value": null},
+		{"op": "replace", "path": "/servers/0/port", "value": 8080},
+		{"op": "remove", "path": "/servers/2"},
+		{"op": "remove", "path": "/servers/1"},
+		{"op": "replace", "path": "/labels/app~1name", "value": "api"},
+		{"op": "remove", "path": "/labels/tier"},
+		{"op": "add", "path": "/labels/Zone", "value": "eu"},
+		{"op": "add", "path": "/labels/env", "value": "prod"},
+		{"op": "add", "path": "/ports/443", "value": true}
+	]`, string(patch))
+
+	err = lookup.ApplyJSONPatch(old, patch)
+	require.NoError(t, err)
+
+	new.Secret = ""
+	assert.Equal(t, new, old)
+
+	patch, err = lookup.CreateJSONPatch(old, old)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(patch))
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	old, new := newGenerateObjs()
+
+	patch, err := lookup.CreateMergePatch(old, new)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "y",
+		"updated": "2026-02-01T12:30:00Z",
+		"server": null,
+		"servers": [{"host": "a", "port": 8080}],
+		"labels": {"app/name": "api", "env": "prod", "tier": null, "Zone": "eu"},
+		"ports": {"443": true}
+	}`, string(patch))
+
+	_, err = lookup.ApplyMergePatch(old, patch)
+	require.NoError(t, err)
+
+	new.Secret = ""
+	assert.Equal(t, new, old)
+
+	patch, err = lookup.CreateMergePatch(old, old)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(patch))
+}
+
+// GenerateBase and GenerateMeta are exported, so they can be embedded.
+type GenerateBase struct {
+	ID   int    `json:"id"`
+	Zone string `json:"zone"`
+}
+
+type GenerateMeta struct {
+	Owner string `json:"owner"`
+}
+
+type generateEmbedded struct {
+	GenerateBase
+	*GenerateMeta
+	Name string `json:"name"`
+}
+
+func TestCreatePatch_Embedded(t *testing.T) {
+	newObjs := func() (*generateEmbedded, *generateEmbedded) {
+		return &generateEmbedded{GenerateBase: GenerateBase{1, "eu"}, Name: "x"},
+			&generateEmbedded{GenerateBase: GenerateBase{2, "eu"}, GenerateMeta: &GenerateMeta{"me"}, Name: "x"}
+	}
+
+	t.Run("json patch", func(t *testing.T) {
+		old, new := newObjs()
+
+		patch, err := lookup.CreateJSONPatch(old, new)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"op": "replace", "path": "/id", "value": 2},
+			{"op": "add", "path": "/owner", "value": "me"}
+		]`, string(patch))
+
+		err = lookup.ApplyJSONPatch(old, patch)
+		require.NoError(t, err)
+		assert.Equal(t, new, old)
+	})
+
+	t.Run("merge patch", func(t *testing.T) {
+		old, new := newObjs()
+
+		patch, err := lookup.CreateMergePatch(old, new)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": 2, "owner": "me"}`, string(patch))
+
+		_, err = lookup.ApplyMergePatch(old, patch)
+		require.NoError(t, err)
+		assert.Equal(t, new, old)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		new, old := newObjs()
+
+		patch, err := lookup.CreateJSONPatch(old, new)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"op": "replace", "path": "/id", "value": 1},
+			{"op": "remove", "path": "/owner"}
+		]`, string(patch))
+
+		patch, err = lookup.CreateMergePatch(old, new)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": 1, "owner": null}`, string(patch))
+	})
+}
+
+func TestCreatePatch_Error(t *testing.T) {
+	_, err := lookup.CreateJSONPatch(&generateObj{}, &patchObj{})
+	assert.ErrorIs(t, err, lookup.ErrTypeMismatch)
+
+	_, err = lookup.CreateMergePatch(1, 2)
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+}
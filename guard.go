@@ -0,0 +1,474 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Policy restricts the paths reachable through a Guarded object. Patterns use
+// the path syntax with `*` for a single field, `[*]` for any index or key and
+// `**` for any number of fields, indexes and keys, e.g. `Users[*].Password`
+// or `Internal.**`. Field names can contain glob patterns like `Pass*`.
+//
+// A path is denied if a Deny pattern matches the path or one of its parents,
+// or if the value at the path can contain a location matched by a Deny
+// pattern. If Allow isn't empty, a pattern has to match the path or one of
+// its parents.
+type Policy struct {
+	Allow []string
+	Deny  []string
+}
+
+// Allows reports whether path is accessible under the policy. t is the type
+// of the root object and decides which children the value at path can have.
+// Without a type every path that can contain a denied location is denied.
+//
+// Malformed patterns fail closed: a bad Deny pattern denies every path, a bad
+// Allow pattern allows none. Indexes and map keys are compared in the form
+// they resolve to, so `[00]` and `[+0]` match a pattern for `[0]`.
+func (p Policy) Allows(t reflect.Type, path string) bool {
+	elements := canonical(t, normalize(path))
+
+	for _, pattern := range p.Deny {
+		pattern := normalize(pattern)
+		if !valid(pattern) {
+			return false
+		}
+
+		covers, rest := match(pattern, elements)
+		if covers {
+			return false
+		}
+
+		if len(rest) > 0 {
+			t := typeOf(t, elements)
+
+			for _, r := range rest {
+				if reachable(t, r, map[visit]bool{}) {
+					return false
+				}
+			}
+		}
+	}
+
+	if len(p.Allow) == 0 {
+		return true
+	}
+
+	for _, pattern := range p.Allow {
+		pattern := normalize(pattern)
+		if !valid(pattern) {
+			continue
+		}
+
+		if covers, _ := match(pattern, elements); covers {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Guarded gives access to an object restricted by a Policy.
+type Guarded struct {
+	obj    any
+	policy Policy
+	opts   []Option
+}
+
+// Guard restricts access to obj to the paths allowed by policy. The options
+// are applied to every operation.
+func Guard(obj any, policy Policy, opts ...Option) *Guarded {
+	return &Guarded{
+		obj:    obj,
+		policy: policy,
+		opts:   opts,
+	}
+}
+
+func (g *Guarded) Exists(path string) (bool, error) {
+	if err := g.check("exists", path); err != nil {
+		return false, err
+	}
+
+	return Exists(g.obj, path, g.opts...)
+}
+
+func (g *Guarded) Get(path string) (any, error) {
+	if err := g.check("get", path); err != nil {
+		return nil, err
+	}
+
+	return Get(g.obj, path, g.opts...)
+}
+
+func (g *Guarded) Create(path string) (any, error) {
+	if err := g.check("create", path); err != nil {
+		return nil, err
+	}
+
+	return Create(g.obj, path, g.opts...)
+}
+
+func (g *Guarded) Set(path string, value any) (any, error) {
+	if err := g.check("set", path); err != nil {
+		return nil, err
+	}
+
+	return Set(g.obj, path, value, g.opts...)
+}
+
+func (g *Guarded) Insert(path string, value any) (any, error) {
+	if err := g.check("insert", path); err != nil {
+		return nil, err
+	}
+
+	return Insert(g.obj, path, value, g.opts...)
+}
+
+func (g *Guarded) Delete(path string) (bool, error) {
+	if err := g.check("delete", path); err != nil {
+		return false, err
+	}
+
+	return Delete(g.obj, path, g.opts...)
+}
+
+func (g *Guarded) check(op string, path string) error {
+	if !g.policy.Allows(reflect.TypeOf(g.obj), path) {
+		return &PathError{Op: op, Path: path, Index: -1, Err: ErrForbidden}
+	}
+
+	return nil
+}
+
+// match compares the pattern with the path elements. covers reports whether
+// the pattern matches the path or one of its parents. rest contains the
+// remaining patterns a child of the path has to match.
+func match(pattern []string, elements []string) (covers bool, rest [][]string) {
+	if len(pattern) == 0 {
+		return true, nil
+	}
+
+	if pattern[0] == "**" {
+		covers, rest = match(pattern[1:], elements)
+
+		if len(elements) > 0 {
+			c, r := match(pattern, elements[1:])
+			covers, rest = covers || c, append(rest, r...)
+		} else {
+			rest = append(rest, pattern)
+		}
+
+		return covers, rest
+	}
+
+	if len(elements) == 0 {
+		return false, [][]string{pattern}
+	}
+
+	if !matchElement(pattern[0], elements[0]) {
+		return false, nil
+	}
+
+	return match(pattern[1:], elements[1:])
+}
+
+// matchElement matches a single field name or `[key]` against a pattern.
+func matchElement(pattern string, element string) bool {
+	if pattern == element {
+		return true
+	}
+
+	key := strings.HasPrefix(pattern, "[")
+	if key != strings.HasPrefix(element, "[") {
+		return false
+	}
+
+	if key {
+		pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "["), "]")
+		element = strings.TrimSuffix(strings.TrimPrefix(element, "["), "]")
+	}
+
+	ok, err := glob(pattern, element)
+	return err == nil && ok
+}
+
+// valid reports whether all elements of the pattern are well-formed.
+func valid(pattern []string) bool {
+	for _, p := range pattern {
+		p = strings.TrimSuffix(strings.TrimPrefix(p, "["), "]")
+
+		if _, err := glob(p, ""); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// glob matches name against the shell pattern like path.Match does, but
+// without a separator, so `*` and `?` also match `/` in map keys. The
+// pattern is checked completely, even if name doesn't match.
+func glob(pattern string, name string) (bool, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return false, err
+	}
+
+	return globMatch(pattern, name), nil
+}
+
+// globMatch matches name against the well-formed pattern.
+func globMatch(pattern string, name string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+			if pattern == "" {
+				return true
+			}
+
+			for i := range name {
+				if globMatch(pattern, name[i:]) {
+					return true
+				}
+			}
+
+			return false
+		case '?':
+			if name == "" {
+				return false
+			}
+
+			_, n := utf8.DecodeRuneInString(name)
+			pattern, name = pattern[1:], name[n:]
+		case '[':
+			if name == "" {
+				return false
+			}
+
+			r, n := utf8.DecodeRuneInString(name)
+
+			ok, rest := matchClass(pattern[1:], r)
+			if !ok {
+				return false
+			}
+
+			pattern, name = rest, name[n:]
+		default:
+			if pattern[0] == '\\' {
+				pattern = pattern[1:]
+			}
+
+			p, n := utf8.DecodeRuneInString(pattern)
+			r, m := utf8.DecodeRuneInString(name)
+
+			if name == "" || p != r {
+				return false
+			}
+
+			pattern, name = pattern[n:], name[m:]
+		}
+	}
+
+	return name == ""
+}
+
+// matchClass matches r against the character class at the start of pattern
+// after the opening `[` and returns the pattern after the class.
+func matchClass(pattern string, r rune) (bool, string) {
+	negated := strings.HasPrefix(pattern, "^")
+	if negated {
+		pattern = pattern[1:]
+	}
+
+	matched := false
+
+	for first := true; first || pattern[0] != ']'; first = false {
+		lo, n := classChar(pattern)
+		pattern = pattern[n:]
+		hi := lo
+
+		if pattern[0] == '-' {
+			hi, n = classChar(pattern[1:])
+			pattern = pattern[1+n:]
+		}
+
+		if lo <= r && r <= hi {
+			matched = true
+		}
+	}
+
+	return matched != negated, pattern[1:]
+}
+
+// classChar returns the possibly escaped character at the start of pattern.
+func classChar(pattern string) (rune, int) {
+	if pattern[0] == '\\' {
+		r, n := utf8.DecodeRuneInString(pattern[1:])
+		return r, n + 1
+	}
+
+	return utf8.DecodeRuneInString(pattern)
+}
+
+// canonical converts the indexes and keys of the path elements on the type t
+// to the form the walk resolves them to. Keys of maps with scalar key types
+// are parsed, indexes converted like strconv.Atoi does. Without a type,
+// numeric keys are treated as indexes.
+func canonical(t reflect.Type, elements []string) []string {
+	result := make([]string, len(elements))
+
+	for i, e := range elements {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		key, ok := strings.CutPrefix(e, "[")
+		if !ok {
+			result[i] = e
+			t = typeOf(t, []string{e})
+
+			continue
+		}
+
+		key = strings.TrimSuffix(key, "]")
+
+		var kind reflect.Kind
+		if t != nil {
+			kind = t.Kind()
+		}
+
+		switch kind {
+		case reflect.Map:
+			if k := t.Key(); k.Kind() != reflect.String && isScalar(k.Kind()) {
+				if v, err := Parse(key, k); err == nil {
+					key = fmt.Sprint(v)
+				}
+			}
+		case reflect.Slice, reflect.Array, reflect.Interface, reflect.Invalid:
+			if n, err := strconv.Atoi(key); err == nil {
+				key = strconv.Itoa(n)
+			}
+		}
+
+		result[i] = "[" + key + "]"
+
+		switch kind {
+		case reflect.Map, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			t = nil
+		}
+	}
+
+	return result
+}
+
+// typeOf returns the type at the path elements or nil if it's unknown.
+func typeOf(t reflect.Type, elements []string) reflect.Type {
+	for _, e := range elements {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t == nil {
+			return nil
+		}
+
+		if strings.HasPrefix(e, "[") {
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				return nil
+			}
+		} else {
+			if t.Kind() != reflect.Struct {
+				return nil
+			}
+
+			f, ok := t.FieldByNameFunc(func(name string) bool {
+				return strings.ToLower(name) == e
+			})
+
+			if !ok {
+				return nil
+			}
+
+			t = f.Type
+		}
+	}
+
+	return t
+}
+
+type visit struct {
+	t    reflect.Type
+	rest int
+}
+
+// reachable reports whether a value of type t can contain a location matched
+// by pattern. Unknown types like interfaces can contain everything.
+func reachable(t reflect.Type, pattern []string, visited map[visit]bool) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() == reflect.Interface {
+		return true
+	}
+
+	if pattern[0] == "**" {
+		if reachable(t, pattern[1:], visited) {
+			return true
+		}
+
+		// recursive types are expanded only once
+		v := visit{t, len(pattern)}
+		if visited[v] {
+			return false
+		}
+
+		visited[v] = true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			if pattern[0] == "**" {
+				if reachable(f.Type, pattern, visited) {
+					return true
+				}
+			} else if matchElement(pattern[0], strings.ToLower(f.Name)) && reachable(f.Type, pattern[1:], visited) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if pattern[0] == "**" {
+			return reachable(t.Elem(), pattern, visited)
+		} else if strings.HasPrefix(pattern[0], "[") {
+			return reachable(t.Elem(), pattern[1:], visited)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestPolicy(t *testing.T) {
+	type user struct {
+		Name     string
+		Password string
+	}
+
+	type obj struct {
+		Users    []user
+		Admin    *user
+		Settings map[string]any
+		Ports    map[int]bool
+	}
+
+	typ := reflect.TypeFor[obj]()
+
+	tests := []struct {
+		name    string
+		policy  lookup.Policy
+		typ     reflect.Type
+		path    string
+		allowed bool
+	}{
+		{"empty policy", lookup.Policy{}, nil, "Users[0].Password", true},
+		{"denied field", lookup.Policy{Deny: []string{"Users[*].Password"}}, nil, "Users[0].Password", false},
+		{"denied case insensitive", lookup.Policy{Deny: []string{"Users[*].Password"}}, nil, "users[1].password", false},
+		{"denied child", lookup.Policy{Deny: []string{"Users[*].Password"}}, nil, "Users[0].Password.Hash", false},
+		{"denied parent", lookup.Policy{Deny: []string{"Users[*].Password"}}, nil, "Users[0]", false},
+		{"denied root", lookup.Policy{Deny: []string{"Users[*].Password"}}, nil, "Users", false},
+		{"sibling", lookup.Policy{Deny: []string{"Users[*].Password"}}, nil, "Users[0].Name", true},
+		{"denied map key", lookup.Policy{Deny: []string{`Secrets["token"]`}}, nil, "Secrets['token']", false},
+		{"other map key", lookup.Policy{Deny: []string{`Secrets["token"]`}}, nil, `Secrets["name"]`, true},
+		{"double star", lookup.Policy{Deny: []string{"Internal.**"}}, nil, "Internal.A[0].B", false},
+		{"double star itself", lookup.Policy{Deny: []string{"Internal.**"}}, nil, "Internal", false},
+		{"double star in the middle", lookup.Policy{Deny: []string{"**.Password"}}, nil, "A.B[0].Password", false},
+		{"double star untyped", lookup.Policy{Deny: []string{"**.Password"}}, nil, "A.B[0].Name", false},
+		{"double star typed", lookup.Policy{Deny: []string{"**.Password"}}, typ, "Users[0].Name", true},
+		{"double star typed parent", lookup.Policy{Deny: []string{"**.Password"}}, typ, "Users", false},
+		{"double star typed pointer", lookup.Policy{Deny: []string{"**.Password"}}, typ, "Admin", false},
+		{"double star interface", lookup.Policy{Deny: []string{"**.Password"}}, typ, "Settings", false},
+		{"typed parent without child", lookup.Policy{Deny: []string{"Users[*].Secret"}}, typ, "Users", true},
+		{"glob", lookup.Policy{Deny: []string{"Pass*"}}, nil, "PassWord", false},
+		{"single star", lookup.Policy{Deny: []string{"*.Secret"}}, nil, "A.Secret", false},
+		{"single star no key", lookup.Policy{Deny: []string{"*.Secret"}}, nil, "A[0].Secret", true},
+		{"star key with slash", lookup.Policy{Deny: []string{"Secrets[*]"}}, nil, `Secrets["a/b"]`, false},
+		{"glob key with slash", lookup.Policy{Deny: []string{"Secrets[a?b*]"}}, nil, `Secrets["a/b/c"]`, false},
+		{"bad deny pattern", lookup.Policy{Deny: []string{"Secrets[[a]"}}, nil, "Public", false},
+		{"bad allow pattern", lookup.Policy{Allow: []string{`Pu*\`}}, nil, "Public", false},
+		{"index with leading zero", lookup.Policy{Deny: []string{"Users[0]"}}, typ, "Users[00]", false},
+		{"index with sign", lookup.Policy{Deny: []string{"Users[0].Password"}}, typ, "Users[+0].Password", false},
+		{"untyped index", lookup.Policy{Deny: []string{"Nums[1]"}}, nil, "Nums[01]", false},
+		{"other index", lookup.Policy{Deny: []string{"Users[0]"}}, typ, "Users[10]", true},
+		{"int map key", lookup.Policy{Deny: []string{"Ports[80]"}}, typ, "Ports[+080]", false},
+		{"string map key", lookup.Policy{Deny: []string{`Settings["0"]`}}, typ, `Settings["00"]`, true},
+		{"allowed", lookup.Policy{Allow: []string{"Public"}}, nil, "Public.Name", true},
+		{"not allowed", lookup.Policy{Allow: []string{"Public"}}, nil, "Private.Name", false},
+		{"allowed parent", lookup.Policy{Allow: []string{"Public.Name"}}, nil, "Public", false},
+		{"allowed but denied", lookup.Policy{Allow: []string{"Public"}, Deny: []string{"Public.Secret"}}, nil, "Public.Secret", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.policy.Allows(tt.typ, tt.path))
+		})
+	}
+}
+
+func TestGuard(t *testing.T) {
+	type user struct {
+		Name     string
+		Password string
+	}
+
+	type obj struct {
+		Users    []user
+		Internal map[string]string
+	}
+
+	o := &obj{
+		Users:    []user{{"alice", "secret"}},
+		Internal: map[string]string{"a": "b"},
+	}
+
+	g := lookup.Guard(o, lookup.Policy{Deny: []string{"Users[*].Password", "Internal.**"}})
+
+	val, err := g.Get("Users[0].Name")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "alice", val)
+	}
+
+	_, err = g.Set("Users[0].Name", "bob")
+	assert.NoError(t, err)
+
+	_, err = g.Get("Users[0].Password")
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	_, err = g.Get("Users[0]")
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	_, err = g.Set("Users[0].Password", "x")
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	_, err = g.Create("Users[1].Password")
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	_, err = g.Insert("Users[0]", user{})
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	_, err = g.Delete(`Internal["a"]`)
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	_, err = g.Exists(`Internal`)
+	assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+	assert.Equal(t, &obj{
+		Users:    []user{{"bob", "secret"}},
+		Internal: map[string]string{"a": "b"},
+	}, o)
+
+	t.Run("index forms", func(t *testing.T) {
+		g := lookup.Guard(o, lookup.Policy{Deny: []string{"Users[0]"}})
+
+		for _, path := range []string{"Users[0].Name", "Users[00].Name", "Users[+0].Name"} {
+			_, err := g.Get(path)
+			assert.ErrorIs(t, err, lookup.ErrForbidden, path)
+		}
+	})
+
+	t.Run("key with slash", func(t *testing.T) {
+		g := lookup.Guard(o, lookup.Policy{Deny: []string{"Internal[*]"}})
+
+		_, err := g.Set(`Internal["a/b"]`, "x")
+		assert.ErrorIs(t, err, lookup.ErrForbidden)
+
+		_, err = g.Get(`Internal["a/b"]`)
+		assert.ErrorIs(t, err, lookup.ErrForbidden)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		g := lookup.Guard(o, lookup.Policy{}, lookup.Strict())
+
+		_, err := g.Set("Users[5].Name", "x")
+		var e *lookup.IndexOutOfRangeError
+		assert.ErrorAs(t, err, &e)
+	})
+}
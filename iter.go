@@ -0,0 +1,84 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"iter"
+	"reflect"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// All returns an iterator over the paths and values of every location of the
+// struct obj in the order of Walk. Nothing is returned if obj isn't a struct.
+func All(obj any) iter.Seq2[string, any] {
+	return locations(obj, false, nil)
+}
+
+// Leaves returns an iterator over the locations of obj without children, like
+// scalars, nil pointers and empty containers.
+func Leaves(obj any) iter.Seq2[string, any] {
+	return locations(obj, true, nil)
+}
+
+// Find returns an iterator over the locations of obj matching pattern. The
+// pattern syntax is the same as for a Policy, e.g. `Users[*].Name` or
+// `**.Password`. Branches that can't match are skipped.
+func Find(obj any, pattern string) iter.Seq2[string, any] {
+	p := normalize(pattern)
+
+	return locations(obj, false, func(path Path) (bool, bool) {
+		elements := normalize(string(path))
+		_, rest := match(p, elements)
+
+		return matches(p, elements), len(rest) > 0
+	})
+}
+
+// locations iterates over obj. filter reports whether a path is returned and
+// whether its children are visited.
+func locations(obj any, leaves bool, filter func(path Path) (bool, bool)) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		v := reflect.ValueOf(obj)
+		if !utils.IsStruct(v) {
+			return
+		}
+
+		fn := func(path Path, v reflect.Value, field *reflect.StructField) error {
+			if filter != nil {
+				ok, more := filter(path)
+				if !ok && !more {
+					return SkipDir
+				}
+
+				if !ok {
+					return nil
+				}
+			}
+
+			if !yield(string(path), v.Interface()) {
+				return SkipAll
+			}
+
+			return nil
+		}
+
+		_ = newVisitor(fn, leaves).run(v)
+	}
+}
+
+// matches reports whether the pattern matches exactly the path elements.
+func matches(pattern []string, elements []string) bool {
+	if len(pattern) == 0 {
+		return len(elements) == 0
+	}
+
+	if pattern[0] == "**" {
+		return matches(pattern[1:], elements) || len(elements) > 0 && matches(pattern, elements[1:])
+	}
+
+	return len(elements) > 0 && matchElement(pattern[0], elements[0]) && matches(pattern[1:], elements[1:])
+}
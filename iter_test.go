@@ -0,0 +1,101 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestAll(t *testing.T) {
+	o := newWalkObj()
+
+	var paths []string
+	for path, v := range lookup.All(o) {
+		paths = append(paths, path)
+
+		val, err := lookup.Get(o, path)
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, val, v, path)
+		}
+	}
+
+	assert.Len(t, paths, 22)
+	assert.Equal(t, "Name", paths[0])
+	assert.Equal(t, "Nil", paths[len(paths)-1])
+
+	t.Run("break", func(t *testing.T) {
+		var paths []string
+
+		for path := range lookup.All(o) {
+			paths = append(paths, path)
+
+			if path == "Tags[0]" {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"Name", "Tags", "Tags[0]"}, paths)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		assert.Empty(t, maps.Collect(lookup.All(1)))
+	})
+}
+
+func TestLeaves(t *testing.T) {
+	o := newWalkObj()
+	o.Tags = nil
+
+	assert.Equal(t, []string{
+		"Name",
+		"Tags",
+		"Array[0]", "Array[1]",
+		`Map['"q"']`, `Map["a.b"]`, `Map["b"]`,
+		"Numbers[2]", "Numbers[10]",
+		`Any["m"]`,
+		"Nested.Name", "Nested.Next.Name", "Nested.Next.Next",
+		"Nil",
+	}, slices.Collect(keys(lookup.Leaves(o))))
+}
+
+func TestFind(t *testing.T) {
+	o := newWalkObj()
+
+	tests := []struct {
+		pattern string
+		paths   []string
+	}{
+		{"Tags[*]", []string{"Tags[0]", "Tags[1]"}},
+		{"Map", []string{"Map"}},
+		{`Map["b"]`, []string{`Map["b"]`}},
+		{"**.Name", []string{"Name", "Nested.Name", "Nested.Next.Name"}},
+		{"Nested.**", []string{"Nested", "Nested.Name", "Nested.Next", "Nested.Next.Name", "Nested.Next.Next"}},
+		{"N*", []string{"Name", "Numbers", "Nested", "Nil"}},
+		{"Unknown.**", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			assert.Equal(t, tt.paths, slices.Collect(keys(lookup.Find(o, tt.pattern))))
+		})
+	}
+}
+
+func keys[K any, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
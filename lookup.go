@@ -9,7 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -17,10 +17,6 @@ import (
 	"github.com/zauberhaus/slice_utils"
 )
 
-var (
-	array = regexp.MustCompile(`(.*)\[(.*)\]`)
-)
-
 type mode int
 
 const (
@@ -28,18 +24,54 @@ const (
 	set
 	exists
 	create
+	remove
+	insert
+	add // insert into slices, set everywhere else
 )
 
-func Exists(obj any, path string) (bool, error) {
-	parts := split(path)
+// allocates reports whether missing intermediates are created on the way.
+func (m mode) allocates() bool {
+	return m != exists && m != remove
+}
+
+// walk is a single operation on a path.
+type walk struct {
+	options
+
+	op    string
+	mode  mode
+	value any
+	path  string // resolved part of the path
+
+	// failure context of the current segment
+	segment  string
+	position int
+	typ      reflect.Type
+
+	allocations int
+	growth      int
 
+	locked error // set in create mode below a read only or immutable field
+}
+
+func newWalk(op string, mode mode, value any, opts ...Option) *walk {
+	return &walk{
+		options:  newOptions(opts...),
+		op:       op,
+		mode:     mode,
+		value:    value,
+		position: -1,
+	}
+}
+
+func Exists(obj any, path string, opts ...Option) (bool, error) {
 	v := reflect.ValueOf(obj)
 
 	if !utils.IsStruct(v) {
-		return false, fmt.Errorf("exists supports only structs")
+		return false, notStruct("exists", valueType(v))
 	}
 
-	result, err := process(v, exists, nil, parts...)
+	result, err := newWalk("exists", exists, nil, opts...).run(v, path)
 	if err != nil {
 		return false, err
 	}
@@ -47,175 +79,337 @@ func Exists(obj any, path string) (bool, error) {
 	return !utils.IsNil(result), nil
 }
 
-func Get(obj any, path string) (any, error) {
-	parts := split(path)
+func Get(obj any, path string, opts ...Option) (any, error) {
+	v := reflect.ValueOf(obj)
 
+	if !utils.IsStruct(v) {
+		return nil, notStruct("get", valueType(v))
+	}
+
+	return newWalk("get", get, nil, opts...).run(v, path)
+}
+
+func Create(obj any, path string, opts ...Option) (any, error) {
 	v := reflect.ValueOf(obj)
 
 	if !utils.IsStruct(v) {
-		return nil, fmt.Errorf("get supports only structs")
+		return nil, notStruct("create", valueType(v))
+	}
+
+	if !utils.IsPointer(v) {
+		return nil, notPointer("create", valueType(v))
 	}
 
-	return process(v, get, nil, parts...)
+	return newWalk("create", create, nil, opts...).run(v, path)
 }
 
-func Create(obj any, path string) (any, error) {
-	parts := split(path)
+func Set(obj any, path string, value any, opts ...Option) (any, error) {
 
 	v := reflect.ValueOf(obj)
 
 	if !utils.IsStruct(v) {
-		return nil, fmt.Errorf("create supports only structs")
+		return nil, notStruct("set", valueType(v))
 	}
 
 	if !utils.IsPointer(v) {
-		return nil, fmt.Errorf("create supports only struct pointers")
+		return nil, notPointer("set", valueType(v))
 	}
 
-	return process(v, create, nil, parts...)
+	return newWalk("set", set, value, opts...).run(v, path)
 }
 
-func Set(obj any, path string, value any) (any, error) {
+// Replace sets the value at path like Set and returns the previous value.
+// The previous value is nil if the location didn't exist.
+func Replace(obj any, path string, value any, opts ...Option) (any, error) {
+	v := reflect.ValueOf(obj)
 
-	parts := split(path)
+	if !utils.IsStruct(v) {
+		return nil, notStruct("replace", valueType(v))
+	}
 
+	if !utils.IsPointer(v) {
+		return nil, notPointer("replace", valueType(v))
+	}
+
+	old, err := newWalk("replace", exists, nil, opts...).run(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = newWalk("replace", set, value, opts...).run(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return old, nil
+}
+
+// CompareAndSet sets the value at path only if the current value deep equals
+// old and reports whether the value was set. old is converted to the type of
+// the current value first, a missing location equals nil.
+func CompareAndSet(obj any, path string, old any, value any, opts ...Option) (bool, error) {
 	v := reflect.ValueOf(obj)
 
 	if !utils.IsStruct(v) {
-		return nil, fmt.Errorf("set supports only structs")
+		return false, notStruct("compare and set", valueType(v))
 	}
 
 	if !utils.IsPointer(v) {
-		return nil, fmt.Errorf("set supports only struct pointers")
+		return false, notPointer("compare and set", valueType(v))
 	}
 
-	return process(v, set, value, parts...)
-}
+	current, err := newWalk("compare and set", exists, nil, opts...).run(v, path)
+	if err != nil {
+		return false, err
+	}
 
-func process(v reflect.Value, mode mode, value any, path ...string) (any, error) {
-	if len(path) == 0 {
-		return nil, nil
+	if !equal(current, old) {
+		return false, nil
 	}
 
-	last := len(path) == 1
+	_, err = newWalk("compare and set", set, value, opts...).run(v, path)
+	if err != nil {
+		return false, err
+	}
 
-	for v.Kind() == reflect.Pointer {
-		v = v.Elem()
+	return true, nil
+}
+
+// equal reports whether old converted to the type of current deep equals it.
+func equal(current any, old any) bool {
+	if utils.IsNil(current) || utils.IsNil(old) {
+		return utils.IsNil(current) && utils.IsNil(old)
 	}
 
+	tmp, err := convertValue(old, reflect.TypeOf(current), false)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(current, tmp.Interface())
+}
+
+// Delete removes the value at path. Map entries are deleted, slice elements
+// are removed and the following elements shifted, `[-1]` removes the last
+// element. Struct fields and array elements are reset to their zero value or
+// their `default` tag. Missing locations are a no-op and return false.
+func Delete(obj any, path string, opts ...Option) (bool, error) {
+	v := reflect.ValueOf(obj)
+
 	if !utils.IsStruct(v) {
-		return nil, fmt.Errorf("field isn't a struct")
+		return false, notStruct("delete", valueType(v))
+	}
+
+	if !utils.IsPointer(v) {
+		return false, notPointer("delete", valueType(v))
+	}
+
+	result, err := newWalk("delete", remove, nil, opts...).run(v, path)
+	if err != nil {
+		return false, err
 	}
 
-	fn := strings.ToLower(path[0])
-	fn = strings.Trim(fn, " \t\n\r")
+	found, _ := result.(bool)
+	return found, nil
+}
 
-	key := any(nil)
+// Insert inserts value into the slice at path before the given index and
+// shifts the following elements. `[]` or an index beyond the end appends the
+// value like Set does.
+func Insert(obj any, path string, value any, opts ...Option) (any, error) {
+	v := reflect.ValueOf(obj)
 
-	matches := array.FindStringSubmatch(fn)
-	if len(matches) == 3 {
-		fn = matches[1]
-		key = matches[2]
+	if !utils.IsStruct(v) {
+		return nil, notStruct("insert", valueType(v))
+	}
 
+	if !utils.IsPointer(v) {
+		return nil, notPointer("insert", valueType(v))
 	}
 
-	var f reflect.Value
-	t := v.Type()
+	return newWalk("insert", insert, value, opts...).run(v, path)
+}
 
-	found := false
-	var fi int
-	for i := 0; i < v.NumField(); i++ {
-		value := v.Field(i)
-		field := t.Field(i)
-		if strings.ToLower(field.Name) == fn {
-			if !field.IsExported() {
-				return nil, fmt.Errorf("field %v is not exported", fn)
-			}
+// run checks path against the limits and processes it on v.
+func (w *walk) run(v reflect.Value, path string) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, w.fail(&PanicError{r})
+		}
+	}()
+
+	if max := w.limits.MaxPathLength; max > 0 && len(path) > max {
+		return nil, w.fail(&LimitError{"path length", max, len(path)})
+	}
 
-			f = value
-			fi = i
-			found = true
-			break
+	if max := w.limits.MaxSegments; max > 0 {
+		if n := len(normalize(path)); n > max {
+			return nil, w.fail(&LimitError{"segments", max, n})
 		}
 	}
 
-	if !found {
-		return nil, &NotFoundError{fn}
+	if err := w.check(v, path); err != nil {
+		return nil, err
+	}
+
+	val, err := w.process(v, split(path)...)
+	if err != nil {
+		return nil, w.fail(err)
 	}
 
-	t = f.Type()
+	return val, nil
+}
 
-	var val any
+// check runs the walk on a copy of v if growth or allocations are limited,
+// so a LimitError is returned before v is changed.
+func (w *walk) check(v reflect.Value, path string) error {
+	if !w.mode.allocates() || v.Kind() != reflect.Pointer || (w.limits.MaxGrowth <= 0 && w.limits.MaxAllocations <= 0) {
+		return nil
+	}
+
+	c, err := clone(v.Interface())
+	if err != nil {
+		return err
+	}
+
+	dry := *w
+
+	_, err = dry.process(reflect.ValueOf(c), split(path)...)
+
+	var limit *LimitError
+	if errors.As(err, &limit) {
+		return dry.fail(err)
+	}
+
+	return nil
+}
+
+// enter appends the next segment applied to a value of type t to the path.
+func (w *walk) enter(segment string, t reflect.Type) {
+	if len(w.path) > 0 && !strings.HasPrefix(segment, "[") {
+		w.path += "."
+	}
 
-	var err error
+	w.path += segment
+	w.segment = segment
+	w.typ = t
+	w.position++
+}
+
+// fail wraps err in a PathError for the current segment.
+func (w *walk) fail(err error) error {
+	return &PathError{
+		Op:      w.op,
+		Path:    w.path,
+		Segment: w.segment,
+		Index:   w.position,
+		Type:    w.typ,
+		Err:     err,
+	}
+}
+
+// missing decides how to continue at a location that doesn't exist and would
+// need n new values. It returns false if the walk stops without a result,
+// with err in strict mode or a LimitError if the allocations are exhausted.
+func (w *walk) missing(err error, n int) (bool, error) {
+	if !w.mode.allocates() {
+		return false, nil
+	}
+
+	if w.locked != nil {
+		return false, w.locked
+	}
+
+	if w.strict {
+		return false, err
+	}
+
+	if max := w.limits.MaxAllocations; max > 0 && w.allocations+n > max {
+		return false, &LimitError{"allocations", max, w.allocations + n}
+	}
+
+	w.allocations += n
+	return true, nil
+}
+
+// location is a value reached while walking a path together with a function
+// writing a replacement back to its parent. commit is set for copies of non
+// addressable values and writes the copy back once the path is processed.
+type location struct {
+	value  reflect.Value
+	assign func(value reflect.Value) error
+	commit func()
+}
+
+func (w *walk) process(v reflect.Value, path ...string) (any, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	last := len(path) == 1
+
+	fn, keys := segment(path[0])
+	name := fieldName(path[0])
+
+	w.enter(name, valueType(v))
+
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if !utils.IsStruct(v) {
+		return nil, kindf(ErrNotStruct, "field isn't a struct")
+	}
+
+	fi, err := w.field(v.Type(), name, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	f := v.Field(fi)
+	t := f.Type()
+
+	var val any
 
 	field := v.Field(fi)
 
-	if mode == set && last && key == nil {
-		tp := v.Type().Field(fi)
-		f := reflect.ValueOf(value)
+	if err := w.protect(v.Type().Field(fi), field); err != nil {
+		return nil, err
+	}
 
-		if field.CanSet() {
-			if (f == reflect.Value{}) {
-				t := field.Type()
-				f = reflect.Zero(t)
-				field.Set(f)
-				val = f.Interface()
-			} else {
-				if field.Type().Kind() != reflect.Interface {
-					if field.Type().Kind() == reflect.Pointer {
-						if f.Kind() != reflect.Pointer {
-							value = utils.CopyToHeap(value)
-							f = reflect.ValueOf(value)
-						}
-					} else {
-						if f.Kind() == reflect.Pointer {
-							value = utils.FromPointer(value)
-							f = reflect.ValueOf(value)
-						}
-					}
-				}
+	if w.mode == remove && last && len(keys) == 0 {
+		if !field.CanSet() {
+			return nil, kindf(ErrNotAddressable, "field isn't addressable: %v", v.Type().Field(fi).Name)
+		}
 
-				if field.Type().Kind() == reflect.Interface {
-					if f.Type().Implements(field.Type()) {
-						field.Set(f)
-						val = value
-					} else {
-						return nil, fmt.Errorf("%v (%v) doesn't implement %v", f, f.Type(), field.Type())
-					}
-				} else if f.Kind() == reflect.String {
-					val, err = Parse(f.String(), field.Type())
-					if err != nil {
-						return nil, err
-					}
-
-					field.Set(reflect.ValueOf(val))
-				} else if f.Kind() == reflect.Pointer && f.Elem().Kind() == reflect.String {
-					val, err = Parse(f.Elem().String(), field.Type())
-					if err != nil {
-						return nil, err
-					}
-
-					field.Set(reflect.ValueOf(val))
-				} else {
-					if f.CanConvert(field.Type()) {
-						f = f.Convert(field.Type())
-						value = f.Interface()
-					} else if field.Type() != f.Type() || (field.Type().Kind() == reflect.Interface && !f.Type().Implements(field.Type())) {
-						return nil, fmt.Errorf("invalid data type %v for %v field", f.Type(), field.Type())
-					}
-
-					field.Set(f)
-					val = value
+		tmp, err := utils.NewWithDefaultsOf(v.Type())
+		if err != nil {
+			return nil, err
+		}
 
-				}
-			}
-		} else {
-			return nil, fmt.Errorf("field isn't addressable: %v", tp.Name)
+		err = keep(w.path, field, func(value reflect.Value) error {
+			field.Set(value)
+			return nil
+		})(reflect.ValueOf(tmp).Field(fi))
+		if err != nil {
+			return nil, err
 		}
-	} else if utils.IsNil(f) && (!last || mode == create) {
-		if mode == exists {
-			return nil, nil
+
+		return true, nil
+	} else if w.mode == insert && last && len(keys) == 0 {
+		return nil, ErrNotSlice
+	} else if (w.mode == set || w.mode == add) && last && len(keys) == 0 {
+		if !field.CanSet() {
+			return nil, kindf(ErrNotAddressable, "field isn't addressable: %v", v.Type().Field(fi).Name)
+		}
+
+		return w.setValue(t, keep(w.path, field, func(value reflect.Value) error {
+			field.Set(value)
+			return nil
+		}))
+	} else if utils.IsNil(f) && len(keys) == 0 && (!last || w.mode == create) {
+		if ok, err := w.missing(&MissingValueError{w.path}, 1); !ok {
+			return nil, err
 		}
 
 		val, err = utils.NewWithDefaultsOf(t)
@@ -225,267 +419,662 @@ func process(v reflect.Value, mode mode, value any, path ...string) (any, error)
 
 		f = reflect.ValueOf(val)
 
-		field := v.Field(fi)
-
 		if field.CanSet() {
 			field.Set(f)
 		} else {
-			return nil, fmt.Errorf("field isn't addressable: %v", field)
+			return nil, kindf(ErrNotAddressable, "field isn't addressable: %v", field)
 		}
 	} else {
 		val = f.Interface()
 	}
 
-	index := -1
-	if key != nil && (f.Kind() == reflect.Slice || f.Kind() == reflect.Array) {
-		if len(key.(string)) == 0 {
-			index = f.Len()
-			key = nil
-		} else {
-			if idx, err := strconv.Atoi(key.(string)); err == nil {
-				index = idx
-				key = nil
+	loc := location{
+		value: f,
+		assign: func(value reflect.Value) error {
+			if !field.CanSet() {
+				return kindf(ErrNotAddressable, "field isn't addressable: %v", field)
 			}
-		}
+
+			field.Set(value)
+			return nil
+		},
 	}
 
-	if index >= 0 {
-		if f.Kind() == reflect.Array {
-			if f.Len() > index {
-				i := f.Index(index)
-				if mode == set && last {
-					val, _, err = setValue(i, i.Type(), value, func(field, f reflect.Value) {
-						field.Set(f)
-					})
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					if i.CanInterface() {
-						val = i.Interface()
-					} else {
-						return nil, fmt.Errorf("field isn't accessible: %v", i)
-					}
-				}
-			} else {
-				return nil, errors.New("array isn't expandable")
-			}
-		} else if f.Kind() == reflect.Slice {
-			l := f.Len()
-			e := t.Elem()
-
-			for i := 0; i <= index; i++ {
-				if i >= l {
-					if mode == exists {
-						return nil, nil
-					}
-
-					tmp, err := utils.NewWithDefaultsOf(e)
-					if err != nil {
-						return nil, err
-					}
-
-					f = reflect.Append(f, reflect.ValueOf(tmp))
-				}
+	var commits []func()
 
-				if i == index {
-					i := f.Index(index)
-
-					if mode == set && last {
-						val, _, err = setValue(i, i.Type(), value, func(field, f reflect.Value) {
-							field.Set(f)
-						})
-
-						if err != nil {
-							return nil, err
-						}
-					} else {
-						if i.CanInterface() {
-							val = i.Interface()
-						}
-					}
-				}
+	for i, key := range keys {
+		var ok bool
+
+		final := last && i == len(keys)-1
+
+		val, loc, ok, err = w.index(loc, key, final)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, nil
+		}
+
+		// create nil pointer elements on demand
+		if loc.value.Kind() == reflect.Pointer && loc.value.IsNil() && (!final || w.mode == create) {
+			if ok, err := w.missing(&MissingValueError{w.path}, 1); !ok {
+				return nil, err
 			}
 
-			field := v.Field(fi)
-			if field.CanSet() {
-				field.Set(f)
-			} else {
-				return nil, fmt.Errorf("field isn't addressable: %v", field)
+			val, err = utils.NewWithDefaultsOf(loc.value.Type())
+			if err != nil {
+				return nil, err
 			}
 
-			f = f.Index(index)
-			if f.CanInterface() {
-				val = f.Interface()
+			f := reflect.ValueOf(val)
+
+			err = loc.assign(f)
+			if err != nil {
+				return nil, err
 			}
 
+			loc.value = f
+		}
+
+		if loc.commit != nil {
+			commits = append(commits, loc.commit)
+		}
+	}
+
+	if !last {
+		rest := path[1:]
+
+		val, err = w.process(loc.value, rest...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		commits[i]()
+	}
+
+	return val, nil
+}
+
+// field returns the index of the field of the struct type t with the lower
+// case name fn and uses its declared name in the resolved path.
+func (w *walk) field(t reflect.Type, name string, fn string) (int, error) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.ToLower(field.Name) != fn {
+			continue
 		}
-	} else if key != nil {
-		if f.Kind() != reflect.Map {
-			return nil, ErrNotMap
+
+		if !field.IsExported() {
+			return 0, kindf(ErrUnexported, "field %v is not exported", fn)
 		}
 
-		// check if map is nil
-		if utils.IsNil(f) {
-			if mode == exists {
-				return nil, nil
+		w.path = strings.TrimSuffix(w.path, name) + field.Name
+		w.segment = field.Name
+
+		return i, nil
+	}
+
+	return 0, &NotFoundError{fn, suggest(fn, t)}
+}
+
+// index resolves a single index or key of the slice, array or map at loc.
+// It returns false if the location doesn't exist and mustn't be created.
+func (w *walk) index(loc location, key string, last bool) (any, location, bool, error) {
+	// dereference pointers to slices, arrays and maps
+	for loc.value.Kind() == reflect.Pointer {
+		p := loc.value
+
+		if p.IsNil() {
+			if ok, err := w.missing(&MissingValueError{w.path}, 1); !ok {
+				return nil, location{}, false, err
 			}
 
-			val, err = utils.NewWithDefaultsOf(t)
+			p = reflect.New(p.Type().Elem())
+
+			err := loc.assign(p)
 			if err != nil {
-				return nil, err
+				return nil, location{}, false, err
+			}
+		}
+
+		e := p.Elem()
+		loc = location{
+			value: e,
+			assign: func(value reflect.Value) error {
+				e.Set(value)
+				return nil
+			},
+		}
+	}
+
+	f := loc.value
+	w.enter("["+key+"]", f.Type())
+
+	if f.Kind() == reflect.Slice || f.Kind() == reflect.Array {
+		idx := f.Len()
+		if len(key) > 0 {
+			i, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, location{}, false, ErrNotMap
 			}
 
-			val = utils.CopyToHeap(val)
-			f = reflect.ValueOf(val).Elem()
+			idx = i
+		}
 
-			field := v.Field(fi)
+		if idx < 0 {
+			// negative indexes count from the end when deleting
+			if w.mode != remove || !last {
+				return nil, location{}, false, fmt.Errorf("invalid index: %v", idx)
+			}
 
-			if field.CanSet() {
-				field.Set(f)
-			} else {
-				return nil, fmt.Errorf("field isn't addressable: %v", field)
+			idx += f.Len()
+			if idx < 0 {
+				return nil, location{}, false, nil
 			}
 		}
 
-		if txt, ok := key.(string); ok {
-			key = strings.Trim(txt, "\"\\`'")
+		if f.Kind() == reflect.Array {
+			return w.indexArray(f, idx, last)
 		}
 
-		// check if key must be parsed
-		e := f.Type().Key()
-		k := reflect.ValueOf(key)
+		return w.indexSlice(loc, idx, last)
+	}
+
+	if f.Kind() != reflect.Map {
+		return nil, location{}, false, ErrNotMap
+	}
+
+	return w.indexMap(loc, key, last)
+}
+
+func (w *walk) indexArray(f reflect.Value, idx int, last bool) (any, location, bool, error) {
+	if idx >= f.Len() {
+		return nil, location{}, false, kindf(ErrNotExpandable, "array isn't expandable")
+	}
+
+	e := f.Index(idx)
+	next := location{
+		value: e,
+		assign: func(value reflect.Value) error {
+			if !e.CanSet() {
+				return kindf(ErrNotAddressable, "field isn't addressable: %v", e.Type())
+			}
+
+			e.Set(value)
+			return nil
+		},
+	}
 
-		if e.Kind() != k.Kind() {
-			if e.Kind() != reflect.String && k.Kind() == reflect.String {
-				val, err := Parse(k.String(), e)
+	if last {
+		switch w.mode {
+		case insert:
+			return nil, location{}, false, kindf(ErrNotExpandable, "array isn't expandable")
+		case remove:
+			// pointers are reset to nil like pointer fields
+			tmp := reflect.Zero(e.Type())
+
+			if e.Kind() != reflect.Pointer {
+				val, err := utils.NewWithDefaultsOf(e.Type())
 				if err != nil {
-					return nil, err
+					return nil, location{}, false, err
 				}
 
-				key = val
-				k = reflect.ValueOf(key)
+				tmp = reflect.ValueOf(val)
+			}
+
+			err := keep(w.path, e, next.assign)(tmp)
+			if err != nil {
+				return nil, location{}, false, err
+			}
+
+			return true, next, true, nil
+		case set, add:
+			val, err := w.setValue(e.Type(), keep(w.path, e, next.assign))
+			if err != nil {
+				return nil, location{}, false, err
 			}
+
+			return val, next, true, nil
+		}
+	}
+
+	return e.Interface(), next, true, nil
+}
+
+func (w *walk) indexSlice(loc location, idx int, last bool) (any, location, bool, error) {
+	f := loc.value
+	l := f.Len()
+	t := f.Type().Elem()
+
+	if last && w.mode == remove {
+		if idx >= l {
+			return nil, location{}, false, nil
 		}
 
-		i := f.MapIndex(k)
+		reflect.Copy(f.Slice(idx, l), f.Slice(idx+1, l))
+		f.Index(l - 1).Set(reflect.Zero(t))
 
-		if mode == set && last {
-			if utils.IsNil(value) {
-				f.SetMapIndex(k, reflect.Value{})
-				val = nil
-				f = reflect.Value{}
-			} else {
-				e := f.Type().Elem()
+		err := loc.assign(f.Slice(0, l-1))
+		if err != nil {
+			return nil, location{}, false, err
+		}
 
-				val, f, err = setValue(f, e, value, func(field, value reflect.Value) {
-					field.SetMapIndex(k, value)
-				})
+		return true, location{}, true, nil
+	}
 
-				if err != nil {
-					return nil, err
-				}
+	if last && (w.mode == insert || w.mode == add) && idx < l {
+		// convert first, so a failure leaves the slice untouched
+		v, err := convertValue(w.value, t, w.lenient)
+		if err != nil {
+			return nil, location{}, false, err
+		}
+
+		f = reflect.Append(f, reflect.Zero(t))
+		reflect.Copy(f.Slice(idx+1, l+1), f.Slice(idx, l))
+		f.Index(idx).Set(v)
+
+		err = loc.assign(f)
+		if err != nil {
+			return nil, location{}, false, err
+		}
+
+		return v.Interface(), location{}, true, nil
+	}
+
+	if idx >= l {
+		// inserting at the end appends without growing
+		if !last || (w.mode != insert && w.mode != add) || idx > l {
+			n := idx + 1 - l
+
+			if max := w.limits.MaxGrowth; max > 0 && w.mode.allocates() && w.growth+n > max {
+				return nil, location{}, false, &LimitError{"growth", max, w.growth + n}
 			}
-		} else {
-			if i.IsValid() {
-				val = i.Interface()
-				f = i
-			} else {
-				if mode == exists {
-					return nil, nil
-				}
 
-				t := f.Type()
-				e := t.Elem()
+			if ok, err := w.missing(&IndexOutOfRangeError{w.path, idx, l}, n); !ok {
+				return nil, location{}, false, err
+			}
 
-				tmp, err := utils.NewWithDefaultsOf(e)
-				if err != nil {
-					return nil, err
-				}
+			w.growth += n
+		}
 
-				val = tmp
-				v := reflect.ValueOf(tmp)
-				f.SetMapIndex(k, v)
-				f = v
+		for i := l; i <= idx; i++ {
+			tmp, err := utils.NewWithDefaultsOf(t)
+			if err != nil {
+				return nil, location{}, false, err
 			}
+
+			f = reflect.Append(f, reflect.ValueOf(tmp))
+		}
+
+		err := loc.assign(f)
+		if err != nil {
+			return nil, location{}, false, err
 		}
 	}
 
-	if last {
-		return val, nil
+	e := f.Index(idx)
+	next := location{
+		value: e,
+		assign: func(value reflect.Value) error {
+			e.Set(value)
+			return nil
+		},
+	}
+
+	if last && (w.mode == set || w.mode == insert || w.mode == add) {
+		assign := next.assign
+		if idx < l {
+			assign = keep(w.path, e, assign)
+		}
+
+		val, err := w.setValue(t, assign)
+		if err != nil {
+			return nil, location{}, false, err
+		}
+
+		return val, next, true, nil
 	}
 
-	rest := path[1:]
-	return process(f, mode, value, rest...)
+	return e.Interface(), next, true, nil
 }
 
-func setValue(field reflect.Value, e reflect.Type, value any, set func(field reflect.Value, value reflect.Value)) (result any, v reflect.Value, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			if e, ok := r.(error); ok {
-				err = e
+func (w *walk) indexMap(loc location, key string, last bool) (any, location, bool, error) {
+	f := loc.value
+	key = unquote(key)
+
+	// check if map is nil
+	if f.IsNil() {
+		if ok, err := w.missing(&MissingKeyError{w.path, key}, 1); !ok {
+			return nil, location{}, false, err
+		}
+
+		tmp, err := utils.NewWithDefaultsOf(f.Type())
+		if err != nil {
+			return nil, location{}, false, err
+		}
+
+		f = reflect.ValueOf(tmp)
+
+		err = loc.assign(f)
+		if err != nil {
+			return nil, location{}, false, err
+		}
+	}
+
+	if last && w.mode == insert {
+		return nil, location{}, false, ErrNotSlice
+	}
+
+	// check if key must be parsed
+	t := f.Type()
+	k := reflect.ValueOf(key)
+
+	if !k.Type().AssignableTo(t.Key()) {
+		tmp, err := Parse(key, t.Key())
+		if err != nil {
+			return nil, location{}, false, &kindError{ErrTypeMismatch, err}
+		}
+
+		k = reflect.ValueOf(tmp)
+	}
+
+	next := location{
+		assign: func(value reflect.Value) error {
+			f.SetMapIndex(k, value)
+			return nil
+		},
+	}
+
+	i := f.MapIndex(k)
+
+	if last && w.mode == remove {
+		if !i.IsValid() {
+			return nil, location{}, false, nil
+		}
+
+		f.SetMapIndex(k, reflect.Value{})
+		return true, location{}, true, nil
+	}
+
+	if last && (w.mode == set || w.mode == add) {
+		if w.strict && !i.IsValid() {
+			return nil, location{}, false, &MissingKeyError{w.path, key}
+		}
+
+		if w.mode == set && utils.IsNil(w.value) {
+			f.SetMapIndex(k, reflect.Value{})
+			return nil, location{}, true, nil
+		}
+
+		assign := next.assign
+		if i.IsValid() {
+			assign = keep(w.path, i, assign)
+		}
+
+		val, err := w.setValue(t.Elem(), assign)
+		if err != nil {
+			return nil, location{}, false, err
+		}
+
+		next.value = f.MapIndex(k)
+		return val, next, true, nil
+	}
+
+	if !i.IsValid() {
+		if ok, err := w.missing(&MissingKeyError{w.path, key}, 1); !ok {
+			return nil, location{}, false, err
+		}
+
+		tmp, err := utils.NewWithDefaultsOf(t.Elem())
+		if err != nil {
+			return nil, location{}, false, err
+		}
+
+		i = reflect.ValueOf(tmp)
+		f.SetMapIndex(k, i)
+	}
+
+	next.value = i
+
+	if !last && (i.Kind() == reflect.Struct || i.Kind() == reflect.Array) {
+		// map values aren't addressable, so work on a copy and write it back
+		tmp := reflect.New(i.Type()).Elem()
+		tmp.Set(i)
+
+		next.value = tmp
+
+		if w.mode != get && w.mode != exists {
+			next.commit = func() {
+				f.SetMapIndex(k, tmp)
 			}
 		}
+	}
+
+	return i.Interface(), next, true, nil
+}
+
+// setValue converts the value of the walk to the type t and stores it using
+// set.
+func (w *walk) setValue(t reflect.Type, set func(value reflect.Value) error) (any, error) {
+	f, err := convertValue(w.value, t, w.lenient)
+	if err != nil {
+		return nil, err
+	}
+
+	err = set(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Interface(), nil
+}
+
+// convertValue converts value to the type t. Strings are parsed, pointers
+// are dereferenced or copied to the heap as required by t and nil results
+// in the zero value. Numbers that don't fit into t fail unless lenient.
+func convertValue(value any, t reflect.Type, lenient bool) (v reflect.Value, err error) {
+	defer func() {
+		if err != nil && !errors.Is(err, ErrTypeMismatch) {
+			err = &kindError{ErrTypeMismatch, err}
+		}
 	}()
 
+	defer catch(&err)
+
 	f := reflect.ValueOf(value)
 
-	if !field.CanSet() {
-		return nil, f, fmt.Errorf("field isn't addressable: %v", field.Type())
+	if (f == reflect.Value{}) {
+		return reflect.Zero(t), nil
 	}
 
-	if field.Type().Kind() == reflect.Interface {
-		if f.Type().Implements(e) {
-			set(field, f)
-			return value, f, nil
-		} else {
-			return nil, f, fmt.Errorf("%v (%v) doesn't implement %v", f, e, field.Type())
+	if t.Kind() == reflect.Interface {
+		if f.Type().Implements(t) {
+			return f, nil
+		}
+
+		return reflect.Value{}, fmt.Errorf("%v (%v) doesn't implement %v", f, f.Type(), t)
+	}
+
+	if f.Kind() == reflect.Pointer && f.IsNil() {
+		// a nil pointer is no value
+		return reflect.Zero(t), nil
+	}
+
+	if t.Kind() == reflect.Pointer {
+		if f.Kind() != reflect.Pointer {
+			f = reflect.ValueOf(utils.CopyToHeap(value))
+		}
+	} else if f.Kind() == reflect.Pointer {
+		f = reflect.ValueOf(utils.FromPointer(value))
+		if !f.IsValid() {
+			// pointer to a nil interface
+			return reflect.Zero(t), nil
 		}
-	} else if f.Kind() == reflect.String {
-		val, err := Parse(f.String(), e)
+	}
+
+	if s := reflect.Indirect(f); s.Kind() == reflect.String {
+		val, err := Parse(s.String(), t)
 		if err != nil {
-			return nil, f, err
+			if v, ok, nerr := parseNumber(s.String(), t, lenient, err); ok {
+				return v, nerr
+			}
+
+			return reflect.Value{}, err
 		}
 
-		f := reflect.ValueOf(val)
-		set(field, f)
-		return val, f, nil
-	} else {
-		if f.CanConvert(field.Type()) {
-			f = f.Convert(field.Type())
-			value = f.Interface()
-		} else if f.Type() != e || (e.Kind() == reflect.Interface && !f.Type().Implements(e)) {
-			return nil, f, fmt.Errorf("invalid data type %v for %v field", e, field.Type())
+		return reflect.ValueOf(val), nil
+	}
+
+	if !lenient {
+		if err := checkNumber(f, t); err != nil {
+			return reflect.Value{}, err
 		}
+	}
+
+	if f.CanConvert(t) {
+		return f.Convert(t), nil
+	} else if f.Type() != t {
+		return reflect.Value{}, fmt.Errorf("invalid data type %v for %v field", f.Type(), t)
+	}
+
+	return f, nil
+}
 
-		set(field, f)
-		return value, f, nil
+func isScalar(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
 	}
+
+	return false
 }
 
-func split(path string) []string {
-	quoted := false
-	braces := false
+// segment splits a path segment like `Map["key"][0]` into the lower case
+// field name and its index or key parts.
+func segment(txt string) (string, []string) {
+	txt = strings.Trim(txt, " \t\n\r")
+
+	start := strings.IndexByte(txt, '[')
+	if start < 0 {
+		return strings.ToLower(txt), nil
+	}
+
+	name := strings.ToLower(strings.Trim(txt[:start], " \t\n\r"))
+
+	var keys []string
+	var quote rune
+	begin := -1
 
-	parts := strings.FieldsFunc(path, func(r rune) bool {
-		if r == '"' || r == '\'' || r == '`' {
-			quoted = !quoted
+	for i, r := range txt[start:] {
+		j := start + i
+
+		switch {
+		case quote != 0:
+			if r == quote && closes(txt, j) {
+				quote = 0
+			}
+		case begin >= 0 && isQuote(r) && opens(txt[begin:j]):
+			quote = r
+		case r == '[' && begin < 0:
+			begin = j + 1
+		case r == ']' && begin >= 0:
+			keys = append(keys, txt[begin:j])
+			begin = -1
 		}
+	}
 
-		if r == '[' {
-			braces = true
+	return name, keys
+}
+
+func isQuote(r rune) bool {
+	return r == '"' || r == '\'' || r == '`'
+}
+
+// opens reports whether a quote after the key prefix starts a quoted key. It
+// may be escaped with a backslash.
+func opens(prefix string) bool {
+	return strings.Trim(prefix, " \t\n\r\\") == ""
+}
+
+// closes reports whether the quote at txt[i] ends a quoted key, which is the
+// case if the key ends after it. Other quotes inside of a key don't have to
+// be escaped.
+func closes(txt string, i int) bool {
+	return strings.HasPrefix(strings.TrimLeft(txt[i+1:], " \t\n\r"), "]")
+}
+
+// valueType returns the type of v or nil if v is invalid.
+func valueType(v reflect.Value) reflect.Type {
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Type()
+}
+
+// fieldName returns the field name of a path segment as written.
+func fieldName(txt string) string {
+	name := strings.TrimSpace(txt)
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+
+	return name
+}
+
+// unquote removes the quotes around a map key. Quotes can be escaped with a
+// backslash.
+func unquote(key string) string {
+	for _, q := range []string{`"`, `'`, "`", `\"`, `\'`} {
+		if len(key) >= 2*len(q) && strings.HasPrefix(key, q) && strings.HasSuffix(key, q) {
+			return key[len(q) : len(key)-len(q)]
 		}
+	}
+
+	return key
+}
 
-		if r == ']' {
-			braces = false
+func split(path string) []string {
+	var (
+		parts []string
+		quote rune
+	)
+
+	begin := -1 // start of the current key
+	last := 0
+
+	for i, r := range path {
+		switch {
+		case quote != 0:
+			if r == quote && (begin < 0 || closes(path, i)) {
+				quote = 0
+			}
+		case isQuote(r) && (begin < 0 || opens(path[begin:i])):
+			quote = r
+		case r == '[' && begin < 0:
+			begin = i + 1
+		case r == ']' && begin >= 0:
+			begin = -1
+		case r == '.' && begin < 0:
+			parts = append(parts, path[last:i])
+			last = i + 1
 		}
+	}
 
-		return !braces && !quoted && r == '.'
-	})
+	parts = append(parts, path[last:])
 
 	parts = slice_utils.Convert(parts, func(val string) string {
 		return strings.Trim(val, "\"'` \t\n\r")
 	})
 
-	return parts
+	// like empty fields, drop fields that only contain quotes or spaces
+	return slices.DeleteFunc(parts, func(val string) bool {
+		return val == ""
+	})
 }
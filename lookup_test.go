@@ -266,6 +266,10 @@ func TestSet_InvalidType(t *testing.T) {
 	}
 
 	_, err = lookup.Set(v, "Value", 1.23) // float64 into int
+	var e *lookup.OverflowError
+	assert.ErrorAs(t, err, &e)
+
+	_, err = lookup.Set(v, "Value", 1.23, lookup.Lenient())
 	assert.NoError(t, err)
 
 	_, err = lookup.Set(v, "Value", true) // bool into int
@@ -1775,3 +1779,635 @@ func isUTC() bool {
 	zoneName, offset := t.Zone()
 	return offset == 0 && (zoneName == "UTC" || zoneName == "GMT")
 }
+
+func TestDelete(t *testing.T) {
+	type item struct {
+		ID   int
+		Name string `default:"unknown"`
+	}
+
+	type obj struct {
+		Name   string `default:"abc"`
+		Value  int
+		Items  []item
+		Tags   []string
+		Array  [3]int
+		Ptrs   [2]*item
+		Map    map[string]int
+		PtrMap map[string]*item
+		Nested *obj
+	}
+
+	newObj := func() *obj {
+		return &obj{
+			Name:   "xyz",
+			Value:  99,
+			Items:  []item{{1, "a"}, {2, "b"}, {3, "c"}},
+			Tags:   []string{"a", "b", "c"},
+			Array:  [3]int{1, 2, 3},
+			Ptrs:   [2]*item{{1, "a"}, {2, "b"}},
+			Map:    map[string]int{"a": 1, "b": 2},
+			PtrMap: map[string]*item{"a": {1, "a"}},
+			Nested: &obj{Name: "n"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		found    bool
+		expected func(o *obj) any
+		want     any
+	}{
+		{"field with default", "Name", true, func(o *obj) any { return o.Name }, "abc"},
+		{"field without default", "Value", true, func(o *obj) any { return o.Value }, 0},
+		{"slice element", "Tags[1]", true, func(o *obj) any { return o.Tags }, []string{"a", "c"}},
+		{"first slice element", "Tags[0]", true, func(o *obj) any { return o.Tags }, []string{"b", "c"}},
+		{"last slice element", "Tags[-1]", true, func(o *obj) any { return o.Tags }, []string{"a", "b"}},
+		{"slice element out of range", "Tags[3]", false, func(o *obj) any { return o.Tags }, []string{"a", "b", "c"}},
+		{"negative index out of range", "Tags[-4]", false, func(o *obj) any { return o.Tags }, []string{"a", "b", "c"}},
+		{"struct slice element", "Items[0]", true, func(o *obj) any { return o.Items }, []item{{2, "b"}, {3, "c"}}},
+		{"field in slice element", "Items[1].Name", true, func(o *obj) any { return o.Items[1] }, item{2, "unknown"}},
+		{"array element", "Array[1]", true, func(o *obj) any { return o.Array }, [3]int{1, 0, 3}},
+		{"pointer array element", "Ptrs[0]", true, func(o *obj) any { return o.Ptrs }, [2]*item{nil, {2, "b"}}},
+		{"pointer field", "Nested", true, func(o *obj) any { return o.Nested }, (*obj)(nil)},
+		{"map entry", "Map[a]", true, func(o *obj) any { return o.Map }, map[string]int{"b": 2}},
+		{"missing map entry", "Map[c]", false, func(o *obj) any { return o.Map }, map[string]int{"a": 1, "b": 2}},
+		{"field in map entry", "PtrMap[a].Name", true, func(o *obj) any { return o.PtrMap["a"] }, &item{1, "unknown"}},
+		{"field in missing map entry", "PtrMap[b].Name", false, func(o *obj) any { return o.PtrMap }, map[string]*item{"a": {1, "a"}}},
+		{"nil pointer", "Nested.Nested.Name", false, func(o *obj) any { return o.Nested.Nested }, (*obj)(nil)},
+		{"nil slice", "Nested.Nested.Tags[0]", false, func(o *obj) any { return o.Nested.Nested }, (*obj)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newObj()
+
+			found, err := lookup.Delete(o, tt.path)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.found, found)
+				assert.Equal(t, tt.want, tt.expected(o))
+			}
+		})
+	}
+
+	t.Run("array out of range", func(t *testing.T) {
+		_, err := lookup.Delete(newObj(), "Array[3]")
+		assert.ErrorContains(t, err, "array isn't expandable")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := lookup.Delete(newObj(), "Unknown")
+		assert.ErrorContains(t, err, "field not found")
+	})
+
+	t.Run("only struct pointers", func(t *testing.T) {
+		_, err := lookup.Delete(*newObj(), "Name")
+		assert.ErrorContains(t, err, "delete supports only struct pointers")
+
+		_, err = lookup.Delete(0, "Name")
+		assert.ErrorContains(t, err, "delete supports only structs")
+	})
+}
+
+func TestInsert(t *testing.T) {
+	type item struct {
+		ID   int
+		Name string `default:"unknown"`
+	}
+
+	type obj struct {
+		Tags   []string
+		Ports  []uint16
+		Items  []item
+		Array  [3]int
+		Map    map[string][]string
+		Matrix [][]int
+		Name   string
+	}
+
+	newObj := func() *obj {
+		return &obj{
+			Tags:   []string{"a", "b", "c"},
+			Ports:  []uint16{80, 443},
+			Items:  []item{{1, "a"}},
+			Map:    map[string][]string{"a": {"x", "y"}},
+			Matrix: [][]int{{1, 2}, {3, 4}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		value    any
+		result   any
+		expected func(o *obj) any
+		want     any
+	}{
+		{"first", "Tags[0]", "x", "x", func(o *obj) any { return o.Tags }, []string{"x", "a", "b", "c"}},
+		{"middle", "Tags[1]", "x", "x", func(o *obj) any { return o.Tags }, []string{"a", "x", "b", "c"}},
+		{"end", "Tags[3]", "x", "x", func(o *obj) any { return o.Tags }, []string{"a", "b", "c", "x"}},
+		{"append", "Tags[]", "x", "x", func(o *obj) any { return o.Tags }, []string{"a", "b", "c", "x"}},
+		{"beyond end", "Tags[5]", "x", "x", func(o *obj) any { return o.Tags }, []string{"a", "b", "c", "", "", "x"}},
+		{"parsed", "Ports[0]", "8080", uint16(8080), func(o *obj) any { return o.Ports }, []uint16{8080, 80, 443}},
+		{"converted", "Ports[1]", 22, uint16(22), func(o *obj) any { return o.Ports }, []uint16{80, 22, 443}},
+		{"struct", "Items[0]", item{2, "b"}, item{2, "b"}, func(o *obj) any { return o.Items }, []item{{2, "b"}, {1, "a"}}},
+		{"slice in map", `Map["a"][1]`, "z", "z", func(o *obj) any { return o.Map["a"] }, []string{"x", "z", "y"}},
+		{"new slice in map", `Map["b"][0]`, "z", "z", func(o *obj) any { return o.Map["b"] }, []string{"z"}},
+		{"nested slice", "Matrix[1][0]", 5, 5, func(o *obj) any { return o.Matrix }, [][]int{{1, 2}, {5, 3, 4}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newObj()
+
+			val, err := lookup.Insert(o, tt.path, tt.value)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.result, val)
+				assert.Equal(t, tt.want, tt.expected(o))
+			}
+		})
+	}
+
+	t.Run("parse error leaves slice untouched", func(t *testing.T) {
+		o := newObj()
+
+		_, err := lookup.Insert(o, "Ports[0]", "invalid")
+		assert.ErrorContains(t, err, "invalid syntax")
+		assert.Equal(t, []uint16{80, 443}, o.Ports)
+	})
+
+	t.Run("not a slice", func(t *testing.T) {
+		o := newObj()
+
+		_, err := lookup.Insert(o, "Name", "x")
+		assert.ErrorIs(t, err, lookup.ErrNotSlice)
+
+		_, err = lookup.Insert(o, `Map["a"]`, []string{"x"})
+		assert.ErrorIs(t, err, lookup.ErrNotSlice)
+
+		_, err = lookup.Insert(o, "Array[0]", 1)
+		assert.ErrorContains(t, err, "array isn't expandable")
+	})
+
+	t.Run("only struct pointers", func(t *testing.T) {
+		_, err := lookup.Insert(*newObj(), "Tags[0]", "x")
+		assert.ErrorContains(t, err, "insert supports only struct pointers")
+	})
+}
+
+func Test_Get_Set_Chained(t *testing.T) {
+	type obj struct {
+		Map    map[string][]string
+		Matrix [][]int
+		Nested map[string]map[string]int
+	}
+
+	o := &obj{}
+
+	val, err := lookup.Set(o, `Map["Key"][1]`, "x")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "x", val)
+		assert.Equal(t, map[string][]string{"Key": {"", "x"}}, o.Map)
+	}
+
+	val, err = lookup.Set(o, "Matrix[1][2]", "3")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, val)
+		assert.Equal(t, [][]int{{}, {0, 0, 3}}, o.Matrix)
+	}
+
+	val, err = lookup.Set(o, `Nested["a"]["b"]`, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, val)
+		assert.Equal(t, map[string]map[string]int{"a": {"b": 1}}, o.Nested)
+	}
+
+	val, err = lookup.Get(o, "Matrix[1][2]")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, val)
+	}
+
+	found, err := lookup.Exists(o, `Map["Key"][2]`)
+	if assert.NoError(t, err) {
+		assert.False(t, found)
+	}
+
+	found, err = lookup.Delete(o, `Map["Key"][0]`)
+	if assert.NoError(t, err) {
+		assert.True(t, found)
+		assert.Equal(t, []string{"x"}, o.Map["Key"])
+	}
+}
+
+func TestReplace(t *testing.T) {
+	type obj struct {
+		Name  string
+		Port  uint16
+		Tags  []string
+		Map   map[string]int
+		Inner *obj
+	}
+
+	o := &obj{Name: "abc", Port: 80, Tags: []string{"a"}}
+
+	tests := []struct {
+		name  string
+		path  string
+		value any
+		old   any
+	}{
+		{"field", "Name", "xyz", "abc"},
+		{"parsed", "Port", "8080", uint16(80)},
+		{"slice element", "Tags[0]", "b", "a"},
+		{"new slice element", "Tags[1]", "c", nil},
+		{"new map entry", `Map["a"]`, 1, nil},
+		{"map entry", `Map["a"]`, 2, 1},
+		{"nil pointer", "Inner.Name", "x", nil},
+		{"created pointer", "Inner.Name", "y", "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, err := lookup.Replace(o, tt.path, tt.value)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.old, old)
+			}
+		})
+	}
+
+	assert.Equal(t, &obj{
+		Name:  "xyz",
+		Port:  8080,
+		Tags:  []string{"b", "c"},
+		Map:   map[string]int{"a": 2},
+		Inner: &obj{Name: "y"},
+	}, o)
+}
+
+func TestCompareAndSet(t *testing.T) {
+	type obj struct {
+		Name  string
+		Port  uint16
+		Tags  []string
+		Map   map[string]int
+		Inner *obj
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		old   any
+		value any
+		set   bool
+		want  any
+	}{
+		{"equal", "Name", "abc", "xyz", true, "xyz"},
+		{"not equal", "Name", "x", "xyz", false, "abc"},
+		{"converted", "Port", 80, 8080, true, uint16(8080)},
+		{"parsed", "Port", "80", 8080, true, uint16(8080)},
+		{"parse error", "Port", "abc", 8080, false, uint16(80)},
+		{"slice", "Tags", []string{"a", "b"}, []string{"c"}, true, []string{"c"}},
+		{"slice not equal", "Tags", []string{"a"}, []string{"c"}, false, []string{"a", "b"}},
+		{"missing map entry", `Map["a"]`, nil, 1, true, 1},
+		{"missing map entry not equal", `Map["a"]`, 0, 1, false, nil},
+		{"nil pointer", "Inner", nil, &obj{Name: "x"}, true, &obj{Name: "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &obj{Name: "abc", Port: 80, Tags: []string{"a", "b"}, Map: map[string]int{}}
+
+			ok, err := lookup.CompareAndSet(o, tt.path, tt.old, tt.value)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.set, ok)
+
+				found, err := lookup.Exists(o, tt.path)
+				require.NoError(t, err)
+
+				if tt.want == nil {
+					assert.False(t, found)
+				} else {
+					val, err := lookup.Get(o, tt.path)
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, val)
+				}
+			}
+		})
+	}
+}
+
+func Test_Map_Values(t *testing.T) {
+	type endpoint struct {
+		Host  string
+		Port  uint16 `default:"8080"`
+		Tags  []string
+		Array [2]int
+		Inner map[string]endpoint
+		Next  *endpoint
+	}
+
+	type obj struct {
+		Endpoints map[string]endpoint
+		Arrays    map[string][3]int
+	}
+
+	newObj := func() *obj {
+		return &obj{
+			Endpoints: map[string]endpoint{
+				"a": {Host: "a", Port: 80, Tags: []string{"x"}},
+			},
+			Arrays: map[string][3]int{"a": {1, 2, 3}},
+		}
+	}
+
+	t.Run("set", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			path     string
+			value    any
+			expected func(o *obj) any
+			want     any
+		}{
+			{"field", `Endpoints["a"].Port`, 443, func(o *obj) any { return o.Endpoints["a"].Port }, uint16(443)},
+			{"new entry", `Endpoints["b"].Host`, "b", func(o *obj) any { return o.Endpoints["b"] }, endpoint{Host: "b", Port: 8080}},
+			{"slice", `Endpoints["a"].Tags[1]`, "y", func(o *obj) any { return o.Endpoints["a"].Tags }, []string{"x", "y"}},
+			{"array", `Endpoints["a"].Array[1]`, 5, func(o *obj) any { return o.Endpoints["a"].Array }, [2]int{0, 5}},
+			{"array value", `Arrays["a"][1]`, 5, func(o *obj) any { return o.Arrays["a"] }, [3]int{1, 5, 3}},
+			{"nested", `Endpoints["a"].Inner["b"].Inner["c"].Port`, 1, func(o *obj) any { return o.Endpoints["a"].Inner["b"].Inner["c"].Port }, uint16(1)},
+			{"nested array", `Endpoints["a"].Inner["b"].Array[0]`, 7, func(o *obj) any { return o.Endpoints["a"].Inner["b"].Array }, [2]int{7, 0}},
+			{"pointer", `Endpoints["a"].Next.Host`, "n", func(o *obj) any { return o.Endpoints["a"].Next }, &endpoint{Host: "n", Port: 8080}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				o := newObj()
+
+				val, err := lookup.Set(o, tt.path, tt.value)
+				if assert.NoError(t, err) {
+					assert.NotNil(t, val)
+					assert.Equal(t, tt.want, tt.expected(o))
+				}
+			})
+		}
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		o := newObj()
+
+		_, err := lookup.Insert(o, `Endpoints["a"].Tags[0]`, "y")
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"y", "x"}, o.Endpoints["a"].Tags)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		o := newObj()
+
+		found, err := lookup.Delete(o, `Endpoints["a"].Port`)
+		if assert.NoError(t, err) {
+			assert.True(t, found)
+			assert.Equal(t, uint16(8080), o.Endpoints["a"].Port)
+		}
+
+		found, err = lookup.Delete(o, `Endpoints["a"].Tags[0]`)
+		if assert.NoError(t, err) {
+			assert.True(t, found)
+			assert.Empty(t, o.Endpoints["a"].Tags)
+		}
+	})
+
+	t.Run("get doesn't write", func(t *testing.T) {
+		o := newObj()
+
+		val, err := lookup.Get(o, `Endpoints["a"].Next.Port`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint16(8080), val)
+			assert.Nil(t, o.Endpoints["a"].Next)
+		}
+	})
+}
+
+func Test_Pointer_Containers(t *testing.T) {
+	type obj struct {
+		Tags   *[]string
+		Labels *map[string]string
+		Array  *[3]int
+		Deep   **[]int
+	}
+
+	t.Run("set", func(t *testing.T) {
+		o := &obj{}
+
+		_, err := lookup.Set(o, "Tags[1]", "b")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &[]string{"", "b"}, o.Tags)
+		}
+
+		_, err = lookup.Set(o, `Labels["a"]`, "x")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &map[string]string{"a": "x"}, o.Labels)
+		}
+
+		_, err = lookup.Set(o, "Array[2]", 3)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &[3]int{0, 0, 3}, o.Array)
+		}
+
+		_, err = lookup.Set(o, "Deep[0]", "1")
+		if assert.NoError(t, err) && assert.NotNil(t, o.Deep) {
+			assert.Equal(t, &[]int{1}, *o.Deep)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		o := &obj{Tags: &[]string{"a"}, Labels: &map[string]string{"a": "x"}}
+
+		val, err := lookup.Get(o, "Tags[0]")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "a", val)
+		}
+
+		val, err = lookup.Get(o, `Labels["a"]`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "x", val)
+		}
+	})
+
+	t.Run("exists doesn't allocate", func(t *testing.T) {
+		o := &obj{}
+
+		found, err := lookup.Exists(o, "Tags[0]")
+		if assert.NoError(t, err) {
+			assert.False(t, found)
+			assert.Nil(t, o.Tags)
+		}
+
+		found, err = lookup.Delete(o, `Labels["a"]`)
+		if assert.NoError(t, err) {
+			assert.False(t, found)
+			assert.Nil(t, o.Labels)
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		o := &obj{}
+
+		_, err := lookup.Create(o, "Tags[0]")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &[]string{""}, o.Tags)
+		}
+	})
+
+	t.Run("insert and delete", func(t *testing.T) {
+		o := &obj{Tags: &[]string{"a", "b"}}
+
+		_, err := lookup.Insert(o, "Tags[0]", "x")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &[]string{"x", "a", "b"}, o.Tags)
+		}
+
+		_, err = lookup.Delete(o, "Tags[1]")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &[]string{"x", "b"}, o.Tags)
+		}
+	})
+}
+
+func TestSet_NilPointer(t *testing.T) {
+	type inner struct {
+		Name string
+	}
+
+	type obj struct {
+		Port  int
+		Name  string
+		Inner inner
+		Ptr   *int
+		Any   any
+	}
+
+	port := 80
+	var empty any
+
+	tests := []struct {
+		name     string
+		path     string
+		value    any
+		expected func(o *obj) any
+		want     any
+	}{
+		{"int", "Port", (*int)(nil), func(o *obj) any { return o.Port }, 0},
+		{"string from other type", "Name", (*float64)(nil), func(o *obj) any { return o.Name }, ""},
+		{"struct", "Inner", (*inner)(nil), func(o *obj) any { return o.Inner }, inner{}},
+		{"pointer", "Ptr", (*int)(nil), func(o *obj) any { return o.Ptr }, (*int)(nil)},
+		{"pointer of other type", "Ptr", (*string)(nil), func(o *obj) any { return o.Ptr }, (*int)(nil)},
+		{"nil interface", "Port", &empty, func(o *obj) any { return o.Port }, 0},
+		{"interface", "Any", (*int)(nil), func(o *obj) any { return o.Any }, (*int)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &obj{Port: 8080, Name: "x", Inner: inner{"y"}, Ptr: &port}
+
+			_, err := lookup.Set(o, tt.path, tt.value)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, tt.expected(o))
+			}
+		})
+	}
+
+	t.Run("copy nil pointer", func(t *testing.T) {
+		type cfg struct {
+			TLS *inner
+			M   inner
+		}
+
+		o := &cfg{M: inner{"x"}}
+
+		err := lookup.Copy(o, "TLS", "M")
+		if assert.NoError(t, err) {
+			assert.Equal(t, inner{}, o.M)
+		}
+	})
+}
+
+func Test_Nil_Pointer_Elements(t *testing.T) {
+	type tls struct {
+		Cert string `default:"server.pem"`
+	}
+
+	type server struct {
+		Name string `default:"localhost"`
+		TLS  *tls
+	}
+
+	type obj struct {
+		Servers []*server
+		Array   [2]*server
+		Hosts   map[string]*server
+	}
+
+	newObj := func() *obj {
+		return &obj{
+			Servers: []*server{nil, nil, nil},
+			Hosts:   map[string]*server{"a": nil},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected func(o *obj) any
+	}{
+		{"slice", "Servers[2].TLS", func(o *obj) any { return o.Servers[2] }},
+		{"array", "Array[1].TLS", func(o *obj) any { return o.Array[1] }},
+		{"map", `Hosts["a"].TLS`, func(o *obj) any { return o.Hosts["a"] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newObj()
+
+			val, err := lookup.Create(o, tt.path)
+			if assert.NoError(t, err) {
+				assert.Equal(t, &tls{"server.pem"}, val)
+				assert.Equal(t, &server{"localhost", &tls{"server.pem"}}, tt.expected(o))
+			}
+
+			o = newObj()
+
+			val, err = lookup.Set(o, tt.path+".Cert", "x.pem")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "x.pem", val)
+				assert.Equal(t, &server{"localhost", &tls{"x.pem"}}, tt.expected(o))
+			}
+		})
+	}
+
+	t.Run("create element", func(t *testing.T) {
+		o := newObj()
+
+		val, err := lookup.Create(o, "Servers[0]")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &server{Name: "localhost"}, val)
+			assert.Equal(t, val, o.Servers[0])
+		}
+	})
+
+	t.Run("exists doesn't create", func(t *testing.T) {
+		o := newObj()
+
+		found, err := lookup.Exists(o, "Servers[1].Name")
+		if assert.NoError(t, err) {
+			assert.False(t, found)
+			assert.Nil(t, o.Servers[1])
+		}
+
+		found, err = lookup.Delete(o, `Hosts["a"].Name`)
+		if assert.NoError(t, err) {
+			assert.False(t, found)
+			assert.Nil(t, o.Hosts["a"])
+		}
+	})
+}
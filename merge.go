@@ -0,0 +1,235 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// ApplyMergePatch applies the JSON Merge Patch document (RFC 7386) to the
+// struct pointer obj. Keys match fields by their `json` name or case
+// insensitive, or are map keys. Objects are merged recursively, also into
+// the map[string]any held by an interface, `null` deletes map entries and
+// resets fields like Delete does, and everything else replaces the location. Scalars are converted like Set does.
+//
+// Keys without a matching field are skipped and returned as JSON Pointers,
+// or fail the patch with the DisallowUnknown option. The patch is atomic like
+// ApplyJSONPatch.
+func ApplyMergePatch(obj any, patch []byte, opts ...Option) (unknown []string, err error) {
+	defer catch(&err)
+
+	v := reflect.ValueOf(obj)
+
+	if !utils.IsStruct(v) {
+		return nil, notStruct("merge patch", valueType(v))
+	}
+
+	if !utils.IsPointer(v) {
+		return nil, notPointer("merge patch", valueType(v))
+	}
+
+	d := json.NewDecoder(bytes.NewReader(patch))
+	d.UseNumber()
+
+	var doc any
+
+	if err := d.Decode(&doc); err != nil {
+		return nil, &PathError{Op: "merge patch", Index: -1, Err: err}
+	}
+
+	values, ok := doc.(map[string]any)
+	if !ok {
+		return nil, &PathError{Op: "merge patch", Index: -1, Err: fmt.Errorf("patch isn't an object")}
+	}
+
+	c, err := clone(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &merger{options: newOptions(opts...), obj: c, opts: opts}
+
+	err = m.merge("", "", reflect.TypeOf(c), values)
+	if err == nil {
+		err = errors.Join(m.errs...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	v.Elem().Set(reflect.ValueOf(c).Elem())
+
+	return m.unknown, nil
+}
+
+type merger struct {
+	options
+
+	obj     any
+	opts    []Option
+	unknown []string // JSON Pointers of unknown keys
+	errs    []error  // unknown keys with DisallowUnknown
+}
+
+// merge merges the object values into the struct or map of type t at path.
+func (m *merger) merge(path Path, pointer string, t reflect.Type, values map[string]any) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		var (
+			child Path
+			typ   reflect.Type
+		)
+
+		ptr := pointer + "/" + pointerEscaper.Replace(key)
+
+		switch t.Kind() {
+		case reflect.Struct:
+			name, field, ok := jsonField(t, key)
+			if !ok {
+				m.unknown = append(m.unknown, ptr)
+
+				if m.disallowUnknown {
+					m.errs = append(m.errs, &PathError{Op: "merge patch", Path: string(path.Field(key)), Index: -1, Type: t, Err: &NotFoundError{key, suggest(key, t)}})
+				}
+
+				continue
+			}
+
+			child, typ = path.Field(string(name)), field
+		case reflect.Map:
+			child, typ = path.Key(key), t.Elem()
+		default:
+			return &PathError{Op: "merge patch", Path: string(path), Index: -1, Type: t, Err: fmt.Errorf("can't merge an object into %v", t)}
+		}
+
+		if err := m.apply(child, ptr, typ, values[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply merges the patch value into the location path of type t.
+func (m *merger) apply(path Path, pointer string, t reflect.Type, value any) error {
+	kind := t.Kind()
+	for kind == reflect.Pointer {
+		t = t.Elem()
+		kind = t.Kind()
+	}
+
+	var err error
+
+	switch val := value.(type) {
+	case nil:
+		_, err = write("merge patch", m.obj, string(path), remove, nil, m.opts...)
+	case map[string]any:
+		if kind == reflect.Interface {
+			// merge into the dynamic value, missing or not an object is empty
+			current, _ := fetch("merge patch", m.obj, string(path), m.opts...)
+
+			merged, err := mergeObject(current, val)
+			if err != nil {
+				return err
+			}
+
+			_, err = write("merge patch", m.obj, string(path), set, merged, m.opts...)
+			return err
+		}
+
+		if kind != reflect.Struct && kind != reflect.Map {
+			return m.replace(path, t, value)
+		}
+
+		if _, err = write("merge patch", m.obj, string(path), create, nil, m.opts...); err == nil {
+			err = m.merge(path, pointer, t, val)
+		}
+	case []any:
+		return m.replace(path, t, value)
+	case json.Number:
+		if kind == reflect.Interface {
+			return m.replace(path, t, value)
+		}
+
+		_, err = write("merge patch", m.obj, string(path), set, val.String(), m.opts...)
+	default:
+		_, err = write("merge patch", m.obj, string(path), set, val, m.opts...)
+	}
+
+	return err
+}
+
+// replace decodes the JSON value into the type t and sets it at path.
+func (m *merger) replace(path Path, t reflect.Type, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	val, err := decode(t, "merge patch", string(path), raw)
+	if err != nil {
+		return err
+	}
+
+	_, err = write("merge patch", m.obj, string(path), set, val, m.opts...)
+	return err
+}
+
+// mergeObject merges the patch object into the dynamic value target like RFC
+// 7386 does and returns the result. target isn't modified and is replaced if
+// it isn't a map[string]any. Values are decoded like json.Unmarshal does.
+func mergeObject(target any, patch map[string]any) (map[string]any, error) {
+	result := map[string]any{}
+
+	if current, ok := target.(map[string]any); ok {
+		maps.Copy(result, current)
+	}
+
+	for key, value := range patch {
+		var err error
+
+		switch val := value.(type) {
+		case nil:
+			delete(result, key)
+		case map[string]any:
+			result[key], err = mergeObject(result[key], val)
+		default:
+			var raw []byte
+
+			if raw, err = json.Marshal(val); err == nil {
+				var decoded any
+
+				err = json.Unmarshal(raw, &decoded)
+				result[key] = decoded
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
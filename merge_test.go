@@ -0,0 +1,160 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		modify  func(o *patchObj)
+		unknown []string
+	}{
+		{"scalars", `{"name": "y", "timeout": "1m"}`, func(o *patchObj) {
+			o.Name = "y"
+			o.Timeout = time.Minute
+		}, nil},
+		{"case insensitive", `{"NAME": "y"}`, func(o *patchObj) {
+			o.Name = "y"
+		}, nil},
+		{"nested", `{"server": {"port": 8080}}`, func(o *patchObj) {
+			o.Server = &patchServer{Port: 8080}
+		}, nil},
+		{"number as string", `{"server": {"host": 1}}`, func(o *patchObj) {
+			o.Server = &patchServer{Host: "1"}
+		}, nil},
+		{"map", `{"labels": {"app/name": null, "tier": "db"}}`, func(o *patchObj) {
+			o.Labels = map[string]string{"tier": "db"}
+		}, nil},
+		{"replace slice", `{"servers": [{"host": "c"}]}`, func(o *patchObj) {
+			o.Servers = []patchServer{{Host: "c"}}
+		}, nil},
+		{"null", `{"name": null, "servers": null}`, func(o *patchObj) {
+			o.Name = ""
+			o.Servers = nil
+		}, nil},
+		{"unknown", `{"nme": "y", "server": {"hots": "h"}, "labels/x": 1}`, func(o *patchObj) {
+			o.Server = &patchServer{}
+		}, []string{"/labels~1x", "/nme", "/server/hots"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newPatchObj()
+			unknown, err := lookup.ApplyMergePatch(o, []byte(tt.patch))
+			require.NoError(t, err)
+			assert.Equal(t, tt.unknown, unknown)
+
+			expected := newPatchObj()
+			tt.modify(expected)
+			assert.Equal(t, expected, o)
+		})
+	}
+}
+
+func TestApplyMergePatch_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		err   error
+	}{
+		{"unknown", `{"name": "y", "nme": "z"}`, nil},
+		{"overflow", `{"name": "y", "server": {"port": 70000}}`, lookup.ErrTypeMismatch},
+		{"read only", `{"name": "y", "id": "2"}`, lookup.ErrReadOnly},
+		{"object into scalar", `{"name": {"a": 1}}`, lookup.ErrTypeMismatch},
+		{"not an object", `[]`, nil},
+		{"invalid", `{`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newPatchObj()
+			_, err := lookup.ApplyMergePatch(o, []byte(tt.patch), lookup.DisallowUnknown())
+			require.Error(t, err)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+
+			assert.Equal(t, newPatchObj(), o)
+		})
+	}
+
+	_, err := lookup.ApplyMergePatch(newPatchObj(), []byte(`{"nme": "z"}`), lookup.DisallowUnknown())
+	assert.ErrorContains(t, err, "did you mean Name?")
+}
+
+func TestApplyMergePatch_Cycle(t *testing.T) {
+	o := newPatchCycle()
+
+	_, err := lookup.ApplyMergePatch(o, []byte(`{"name": "y", "n": {"next": {"name": "m"}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "y", o.Name)
+	assert.Equal(t, "m", o.N.Name)
+	assert.Same(t, o.N, o.N.Next)
+}
+
+func TestApplyMergePatch_Dynamic(t *testing.T) {
+	type obj struct {
+		Meta map[string]any `json:"meta"`
+		Any  any            `json:"any"`
+	}
+
+	newObj := func() *obj {
+		return &obj{
+			Meta: map[string]any{"x": map[string]any{"a": 1, "b": 2}, "y": "z"},
+			Any:  "s",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		patch    string
+		expected *obj
+	}{
+		{"merge nested map", `{"meta": {"x": {"a": null, "c": {"d": 3}}}}`, &obj{
+			Meta: map[string]any{"x": map[string]any{"b": 2, "c": map[string]any{"d": float64(3)}}, "y": "z"},
+			Any:  "s",
+		}},
+		{"object replaces scalar", `{"meta": {"y": {"a": null, "b": [1, null]}}}`, &obj{
+			Meta: map[string]any{"x": map[string]any{"a": 1, "b": 2}, "y": map[string]any{"b": []any{float64(1), nil}}},
+			Any:  "s",
+		}},
+		{"interface field", `{"any": {"a": "b", "c": null}}`, &obj{
+			Meta: map[string]any{"x": map[string]any{"a": 1, "b": 2}, "y": "z"},
+			Any:  map[string]any{"a": "b"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newObj()
+
+			_, err := lookup.ApplyMergePatch(o, []byte(tt.patch))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, o)
+		})
+	}
+
+	t.Run("last key", func(t *testing.T) {
+		x := map[string]any{"a": 1}
+		o := &obj{Meta: map[string]any{"x": x}}
+
+		_, err := lookup.ApplyMergePatch(o, []byte(`{"meta": {"x": {"a": null}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": 1}, x)
+		assert.Equal(t, map[string]any{}, o.Meta["x"])
+	})
+}
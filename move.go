@@ -0,0 +1,296 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// Copy copies the value at from to the location to. Like a JSON Patch copy,
+// a slice index as destination inserts the value and every other location is
+// overwritten. The value is deep copied and converted if the types differ.
+func Copy(obj any, from string, to string, opts ...Option) error {
+	return CopyTo(obj, from, obj, to, opts...)
+}
+
+// CopyTo copies the value at from in src to the location to in dst.
+func CopyTo(src any, from string, dst any, to string, opts ...Option) error {
+	val, err := fetch("copy", src, from, opts...)
+	if err != nil {
+		return err
+	}
+
+	val, err = clone(val)
+	if err != nil {
+		return err
+	}
+
+	_, err = write("copy", dst, to, add, val, opts...)
+	return err
+}
+
+// Move removes the value at from and adds it at the location to, like a JSON
+// Patch move. The destination path is resolved after the removal.
+func Move(obj any, from string, to string, opts ...Option) error {
+	return MoveTo(obj, from, obj, to, opts...)
+}
+
+// MoveTo moves the value at from in src to the location to in dst.
+func MoveTo(src any, from string, dst any, to string, opts ...Option) error {
+	if identical(src, dst) {
+		if same(from, to) {
+			return nil
+		}
+
+		if inside(from, to) {
+			return &PathError{Op: "move", Path: to, Index: -1, Err: fmt.Errorf("can't move %v into itself", from)}
+		}
+	}
+
+	val, err := fetch("move", src, from, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = write("move", src, from, remove, nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = write("move", dst, to, add, val, opts...)
+	if err != nil {
+		// restore the source
+		_, _ = write("move", src, from, add, val, opts...)
+		return err
+	}
+
+	return nil
+}
+
+// Swap exchanges the values at the paths a and b.
+func Swap(obj any, a string, b string, opts ...Option) error {
+	return SwapWith(obj, a, obj, b, opts...)
+}
+
+// SwapWith exchanges the value at a in obj with the value at b in other.
+func SwapWith(obj any, a string, other any, b string, opts ...Option) error {
+	if identical(obj, other) && (inside(a, b) || inside(b, a)) {
+		if same(a, b) {
+			return nil
+		}
+
+		return &PathError{Op: "swap", Path: b, Index: -1, Err: fmt.Errorf("can't swap %v with a part of itself", a)}
+	}
+
+	va, err := fetch("swap", obj, a, opts...)
+	if err != nil {
+		return err
+	}
+
+	vb, err := fetch("swap", other, b, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = write("swap", obj, a, set, vb, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = write("swap", other, b, set, va, opts...)
+	if err != nil {
+		// restore the first value
+		_, _ = write("swap", obj, a, set, va, opts...)
+		return err
+	}
+
+	return nil
+}
+
+// fetch returns the value at path without creating missing locations.
+func fetch(op string, obj any, path string, opts ...Option) (any, error) {
+	v := reflect.ValueOf(obj)
+
+	if !utils.IsStruct(v) {
+		return nil, notStruct(op, valueType(v))
+	}
+
+	result, err := newWalk(op, exists, nil, opts...).run(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, &PathError{Op: op, Path: path, Index: -1, Err: &NotFoundError{Name: path}}
+	}
+
+	return result, nil
+}
+
+// write runs process in mode on the struct pointer obj.
+func write(op string, obj any, path string, mode mode, value any, opts ...Option) (any, error) {
+	v := reflect.ValueOf(obj)
+
+	if !utils.IsStruct(v) {
+		return nil, notStruct(op, valueType(v))
+	}
+
+	if !utils.IsPointer(v) {
+		return nil, notPointer(op, valueType(v))
+	}
+
+	return newWalk(op, mode, value, opts...).run(v, path)
+}
+
+// clone returns a deep copy of val. Pointers, slices and maps that are shared
+// or part of a cycle in val are shared or cyclic in the copy as well.
+// Unexported fields can't be reached by a path and are copied shallow.
+func clone(val any) (result any, err error) {
+	defer catch(&err)
+
+	if val == nil {
+		return nil, nil
+	}
+
+	c := &cloner{copies: map[reference]reflect.Value{}}
+
+	return c.copy(reflect.ValueOf(val)).Interface(), nil
+}
+
+type cloner struct {
+	copies map[reference]reflect.Value // copied pointers, slices and maps
+}
+
+func (c *cloner) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		ref := reference{v.Pointer(), v.Type(), 0}
+		if p, ok := c.copies[ref]; ok {
+			return p
+		}
+
+		p := reflect.New(v.Type().Elem())
+		c.copies[ref] = p
+		p.Elem().Set(c.copy(v.Elem()))
+
+		return p
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		r := reflect.New(v.Type()).Elem()
+		r.Set(c.copy(v.Elem()))
+
+		return r
+	case reflect.Struct:
+		r := reflect.New(v.Type()).Elem()
+		r.Set(v)
+
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				r.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+
+		return r
+	case reflect.Array:
+		r := reflect.New(v.Type()).Elem()
+
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(c.copy(v.Index(i)))
+		}
+
+		return r
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		ref := reference{v.Pointer(), v.Type(), v.Len()}
+		if s, ok := c.copies[ref]; ok {
+			return s
+		}
+
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		c.copies[ref] = s
+
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(c.copy(v.Index(i)))
+		}
+
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		ref := reference{v.Pointer(), v.Type(), 0}
+		if m, ok := c.copies[ref]; ok {
+			return m
+		}
+
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[ref] = m
+
+		for it := v.MapRange(); it.Next(); {
+			m.SetMapIndex(it.Key(), c.copy(it.Value()))
+		}
+
+		return m
+	default:
+		return v
+	}
+}
+
+// identical reports whether both objects are the same struct pointer.
+func identical(a any, b any) bool {
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+
+	return va.Kind() == reflect.Pointer && vb.Kind() == reflect.Pointer && va.Pointer() == vb.Pointer()
+}
+
+// inside reports whether path is located at or below parent.
+func inside(parent string, path string) bool {
+	p := normalize(parent)
+	c := normalize(path)
+
+	if len(p) > len(c) {
+		return false
+	}
+
+	return slices.Equal(p, c[:len(p)])
+}
+
+// same reports whether both paths point to the same location.
+func same(a string, b string) bool {
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+// normalize returns the field names and keys of path as a flat list.
+func normalize(path string) []string {
+	var result []string
+
+	for _, part := range split(path) {
+		name, keys := segment(part)
+		result = append(result, name)
+
+		for _, key := range keys {
+			result = append(result, "["+unquote(key)+"]")
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type moveItem struct {
+	ID   int
+	Name string `default:"unknown"`
+}
+
+type moveObj struct {
+	Name  string
+	Port  uint16
+	Label string
+	Text  string
+	Tags  []string
+	Items []moveItem
+	Map   map[string]moveItem
+	Item  *moveItem
+}
+
+func newMoveObj() *moveObj {
+	return &moveObj{
+		Name:  "abc",
+		Port:  80,
+		Text:  "8080",
+		Tags:  []string{"a", "b", "c"},
+		Items: []moveItem{{1, "a"}, {2, "b"}},
+		Map:   map[string]moveItem{"x": {9, "x"}},
+	}
+}
+
+func TestCopy(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected func(o *moveObj) any
+		want     any
+	}{
+		{"field", "Name", "Label", func(o *moveObj) any { return o.Label }, "abc"},
+		{"string to number", "Text", "Port", func(o *moveObj) any { return o.Port }, uint16(8080)},
+		{"insert into slice", "Name", "Tags[1]", func(o *moveObj) any { return o.Tags }, []string{"a", "abc", "b", "c"}},
+		{"append to slice", "Name", "Tags[]", func(o *moveObj) any { return o.Tags }, []string{"a", "b", "c", "abc"}},
+		{"map entry", `Map["x"]`, `Map["y"]`, func(o *moveObj) any { return o.Map["y"] }, moveItem{9, "x"}},
+		{"struct to pointer", "Items[1]", "Item", func(o *moveObj) any { return o.Item }, &moveItem{2, "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newMoveObj()
+
+			err := lookup.Copy(o, tt.from, tt.to)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, tt.expected(o))
+			}
+		})
+	}
+
+	t.Run("cycle", func(t *testing.T) {
+		type node struct {
+			Name string
+			Next *node
+		}
+
+		type obj struct {
+			A *node
+			B *node
+		}
+
+		n := &node{Name: "a"}
+		n.Next = n
+		o := &obj{A: n}
+
+		err := lookup.Copy(o, "A", "B")
+		require.NoError(t, err)
+
+		assert.NotSame(t, o.A, o.B)
+		assert.Same(t, o.B, o.B.Next)
+		assert.Equal(t, "a", o.B.Name)
+	})
+
+	t.Run("deep copy", func(t *testing.T) {
+		type obj struct {
+			A []string
+			B []string
+		}
+
+		o := &obj{A: []string{"a"}}
+
+		err := lookup.Copy(o, "A", "B")
+		if assert.NoError(t, err) {
+			o.A[0] = "x"
+			assert.Equal(t, []string{"a"}, o.B)
+		}
+	})
+
+	t.Run("between objects", func(t *testing.T) {
+		type other struct {
+			Count int64
+		}
+
+		src := newMoveObj()
+		dst := &other{}
+
+		err := lookup.CopyTo(src, "Port", dst, "Count")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(80), dst.Count)
+		}
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		err := lookup.Copy(newMoveObj(), `Map["z"]`, "Item")
+		assert.ErrorContains(t, err, "field not found")
+	})
+}
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected func(o *moveObj) any
+		want     any
+	}{
+		{"field", "Name", "Label", func(o *moveObj) any { return []string{o.Name, o.Label} }, []string{"", "abc"}},
+		{"slice element", "Tags[0]", "Tags[2]", func(o *moveObj) any { return o.Tags }, []string{"b", "c", "a"}},
+		{"slice element backwards", "Tags[2]", "Tags[0]", func(o *moveObj) any { return o.Tags }, []string{"c", "a", "b"}},
+		{"map entry", `Map["x"]`, `Map["y"]`, func(o *moveObj) any { return o.Map }, map[string]moveItem{"y": {9, "x"}}},
+		{"map entry to slice", `Map["x"]`, "Items[0]", func(o *moveObj) any { return o.Items }, []moveItem{{9, "x"}, {1, "a"}, {2, "b"}}},
+		{"same location", "Tags[1]", "tags[1]", func(o *moveObj) any { return o.Tags }, []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newMoveObj()
+
+			err := lookup.Move(o, tt.from, tt.to)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, tt.expected(o))
+			}
+		})
+	}
+
+	t.Run("into itself", func(t *testing.T) {
+		err := lookup.Move(newMoveObj(), "Items", "Items[0].Name")
+		assert.ErrorContains(t, err, "into itself")
+	})
+
+	t.Run("failure restores source", func(t *testing.T) {
+		o := newMoveObj()
+
+		err := lookup.Move(o, "Tags[0]", "Port")
+		assert.ErrorContains(t, err, "invalid syntax")
+		assert.Equal(t, []string{"a", "b", "c"}, o.Tags)
+	})
+}
+
+func TestSwap(t *testing.T) {
+	t.Run("slice elements", func(t *testing.T) {
+		o := newMoveObj()
+
+		err := lookup.Swap(o, "Tags[0]", "Tags[2]")
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"c", "b", "a"}, o.Tags)
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		o := newMoveObj()
+		o.Label = "xyz"
+
+		err := lookup.Swap(o, "Name", "Label")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "xyz", o.Name)
+			assert.Equal(t, "abc", o.Label)
+		}
+	})
+
+	t.Run("between objects", func(t *testing.T) {
+		a := newMoveObj()
+		b := newMoveObj()
+		b.Name = "xyz"
+
+		err := lookup.SwapWith(a, "Name", b, "Name")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "xyz", a.Name)
+			assert.Equal(t, "abc", b.Name)
+		}
+	})
+
+	t.Run("failure restores values", func(t *testing.T) {
+		o := newMoveObj()
+
+		err := lookup.Swap(o, "Name", "Port")
+		assert.ErrorContains(t, err, "invalid syntax")
+		assert.Equal(t, "abc", o.Name)
+		assert.Equal(t, uint16(80), o.Port)
+	})
+
+	t.Run("part of itself", func(t *testing.T) {
+		err := lookup.Swap(newMoveObj(), "Items[0]", "Items[0].Name")
+		assert.ErrorContains(t, err, "part of itself")
+	})
+}
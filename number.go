@@ -0,0 +1,137 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// checkNumber returns an OverflowError if converting the number v to the
+// numeric type t overflows, loses the sign or truncates a fraction. Floats
+// may lose precision, but not overflow.
+func checkNumber(v reflect.Value, t reflect.Type) error {
+	fail := func(reason string) error {
+		return &OverflowError{Value: v.Interface(), Type: t, Reason: reason}
+	}
+
+	switch {
+	case isInt(t.Kind()):
+		switch {
+		case isInt(v.Kind()):
+			if t.OverflowInt(v.Int()) {
+				return fail(Overflow)
+			}
+		case isUint(v.Kind()):
+			if v.Uint() > math.MaxInt64 || t.OverflowInt(int64(v.Uint())) {
+				return fail(Overflow)
+			}
+		case isFloat(v.Kind()):
+			f := v.Float()
+
+			switch {
+			case math.IsNaN(f) || math.Trunc(f) != f:
+				return fail(Truncation)
+			case f < math.MinInt64 || f >= math.MaxInt64 || t.OverflowInt(int64(f)):
+				return fail(Overflow)
+			}
+		}
+	case isUint(t.Kind()):
+		switch {
+		case isInt(v.Kind()):
+			if v.Int() < 0 {
+				return fail(SignLoss)
+			}
+
+			if t.OverflowUint(uint64(v.Int())) {
+				return fail(Overflow)
+			}
+		case isUint(v.Kind()):
+			if t.OverflowUint(v.Uint()) {
+				return fail(Overflow)
+			}
+		case isFloat(v.Kind()):
+			f := v.Float()
+
+			switch {
+			case math.IsNaN(f) || math.Trunc(f) != f:
+				return fail(Truncation)
+			case f < 0:
+				return fail(SignLoss)
+			case f >= math.MaxUint64 || t.OverflowUint(uint64(f)):
+				return fail(Overflow)
+			}
+		}
+	case isFloat(t.Kind()):
+		if isFloat(v.Kind()) && !math.IsInf(v.Float(), 0) && t.OverflowFloat(v.Float()) {
+			return fail(Overflow)
+		}
+	}
+
+	return nil
+}
+
+// parseNumber parses txt as a number for the numeric type t or a pointer to
+// it after Parse failed with cause. Numbers out of range fail with an
+// OverflowError unless lenient, like numbers that aren't strings. ok is false
+// if txt isn't a number or t isn't numeric.
+func parseNumber(txt string, t reflect.Type, lenient bool, cause error) (v reflect.Value, ok bool, err error) {
+	e := t
+	for e.Kind() == reflect.Pointer {
+		e = e.Elem()
+	}
+
+	if !isInt(e.Kind()) && !isUint(e.Kind()) && !isFloat(e.Kind()) {
+		return reflect.Value{}, false, nil
+	}
+
+	txt = strings.TrimSpace(txt)
+
+	if i, err := strconv.ParseInt(txt, 10, 64); err == nil {
+		v = reflect.ValueOf(i)
+	} else if u, err := strconv.ParseUint(txt, 10, 64); err == nil {
+		v = reflect.ValueOf(u)
+	} else if f, err := strconv.ParseFloat(txt, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		v = reflect.ValueOf(f)
+	} else {
+		return reflect.Value{}, false, nil
+	}
+
+	if !lenient {
+		if err := checkNumber(v, e); err != nil {
+			if o, ok := err.(*OverflowError); ok {
+				o.Err = cause
+			}
+
+			return reflect.Value{}, true, err
+		}
+	}
+
+	v = v.Convert(e)
+
+	for e != t {
+		p := reflect.New(e)
+		p.Elem().Set(v)
+		v, e = p, p.Type()
+	}
+
+	return v, true, nil
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+// Option configures a single path operation.
+type Option func(o *options)
+
+type options struct {
+	strict  bool
+	lenient bool
+	limits  Limits
+
+	containers bool // Flatten
+	pointers   bool // Flatten and Unflatten
+
+	keys []keyField // Diff and Merge
+
+	slices []sliceRule // Merge
+	maps   []mapRule   // Merge
+
+	disallowUnknown bool // ApplyMergePatch
+}
+
+// keyField identifies the elements of the slices matching pattern by field.
+type keyField struct {
+	pattern []string
+	field   string
+}
+
+// SliceStrategy decides how Merge combines slices.
+type SliceStrategy int
+
+const (
+	ReplaceSlice SliceStrategy = iota // the slice of src replaces the one of dst
+	AppendSlice                       // the elements of src are appended
+	MergeSlice                        // elements are merged by their MatchBy key or index
+)
+
+// MapStrategy decides how Merge combines maps.
+type MapStrategy int
+
+const (
+	MergeMap   MapStrategy = iota // the entries of src are merged into dst
+	ReplaceMap                    // the map of src replaces the one of dst
+)
+
+type sliceRule struct {
+	pattern  []string
+	strategy SliceStrategy
+}
+
+type mapRule struct {
+	pattern  []string
+	strategy MapStrategy
+}
+
+// Limits restricts operations on untrusted paths. Zero means unlimited.
+type Limits struct {
+	MaxPathLength  int // length of the path in bytes
+	MaxSegments    int // number of fields, indexes and keys in the path
+	MaxGrowth      int // slice elements appended by auto growing
+	MaxAllocations int // values created for missing locations
+}
+
+func newOptions(opts ...Option) options {
+	var o options
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Strict only allows writes to existing locations. Instead of growing slices,
+// adding map entries or creating nil intermediates, the operation fails with
+// an IndexOutOfRangeError, a MissingKeyError or a MissingValueError.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithLimits checks operations against limits and fails with a LimitError if
+// one is exceeded. The path is checked before the walk starts, growth and
+// allocations by a first walk on a copy of the object, so the object is
+// unchanged if a limit is exceeded.
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+// Lenient converts numbers like a Go conversion does, so values that don't
+// fit into the numeric type of a location are truncated instead of failing
+// with an OverflowError.
+func Lenient() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// Containers makes Flatten include structs, slices, arrays and maps besides
+// the leaves.
+func Containers() Option {
+	return func(o *options) {
+		o.containers = true
+	}
+}
+
+// JSONPointer makes Flatten and Unflatten use JSON Pointers like
+// `/Address/City` as keys instead of paths.
+func JSONPointer() Option {
+	return func(o *options) {
+		o.pointers = true
+	}
+}
+
+// MatchBy makes Diff and Merge match the struct elements of the slices matching
+// pattern by the value of their field instead of their index. The pattern
+// syntax is the same as for a Policy.
+func MatchBy(pattern string, field string) Option {
+	return func(o *options) {
+		o.keys = append(o.keys, keyField{normalize(pattern), field})
+	}
+}
+
+// DisallowUnknown makes ApplyMergePatch fail on keys without a matching
+// field instead of reporting them.
+func DisallowUnknown() Option {
+	return func(o *options) {
+		o.disallowUnknown = true
+	}
+}
+
+// WithSliceStrategy sets the strategy of Merge for the slices matching
+// pattern, e.g. `**` for all slices. The last matching rule wins, slices
+// without a rule are replaced.
+func WithSliceStrategy(pattern string, strategy SliceStrategy) Option {
+	return func(o *options) {
+		o.slices = append(o.slices, sliceRule{normalize(pattern), strategy})
+	}
+}
+
+// WithMapStrategy sets the strategy of Merge for the maps matching pattern.
+// The last matching rule wins, maps without a rule are merged.
+func WithMapStrategy(pattern string, strategy MapStrategy) Option {
+	return func(o *options) {
+		o.maps = append(o.maps, mapRule{normalize(pattern), strategy})
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestStrict(t *testing.T) {
+	type item struct {
+		Name string
+	}
+
+	type obj struct {
+		Name    string
+		Items   []string
+		Map     map[string]int
+		NilMap  map[string]int
+		Nested  *item
+		Pointer []*item
+		Tags    *[]string
+	}
+
+	newObj := func() *obj {
+		return &obj{
+			Items:   []string{"a"},
+			Map:     map[string]int{"a": 1},
+			Pointer: []*item{nil},
+		}
+	}
+
+	t.Run("existing locations", func(t *testing.T) {
+		o := newObj()
+
+		for path, value := range map[string]any{
+			"Name":     "x",
+			"Items[0]": "b",
+			`Map["a"]`: 2,
+			"Nested":   &item{},
+		} {
+			_, err := lookup.Set(o, path, value, lookup.Strict())
+			assert.NoError(t, err, path)
+		}
+
+		assert.Equal(t, &obj{
+			Name:    "x",
+			Items:   []string{"b"},
+			Map:     map[string]int{"a": 2},
+			Nested:  &item{},
+			Pointer: []*item{nil},
+		}, o)
+	})
+
+	tests := []struct {
+		name  string
+		path  string
+		check func(t *testing.T, err error)
+	}{
+		{"index out of range", "Items[5]", func(t *testing.T, err error) {
+			var e *lookup.IndexOutOfRangeError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, "Items[5]", e.Path)
+				assert.Equal(t, 5, e.Index)
+				assert.Equal(t, 1, e.Len)
+			}
+		}},
+		{"append", "Items[]", func(t *testing.T, err error) {
+			var e *lookup.IndexOutOfRangeError
+			assert.ErrorAs(t, err, &e)
+		}},
+		{"missing key", `Map["b"]`, func(t *testing.T, err error) {
+			var e *lookup.MissingKeyError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, "b", e.Key)
+			}
+		}},
+		{"nil map", `NilMap["b"]`, func(t *testing.T, err error) {
+			var e *lookup.MissingKeyError
+			assert.ErrorAs(t, err, &e)
+		}},
+		{"nil pointer", "Nested.Name", func(t *testing.T, err error) {
+			var e *lookup.MissingValueError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, "Nested", e.Path)
+			}
+		}},
+		{"nil element", "Pointer[0].Name", func(t *testing.T, err error) {
+			var e *lookup.MissingValueError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, "Pointer[0]", e.Path)
+			}
+		}},
+		{"nil pointer to slice", "Tags[0]", func(t *testing.T, err error) {
+			var e *lookup.MissingValueError
+			assert.ErrorAs(t, err, &e)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newObj()
+
+			_, err := lookup.Set(o, tt.path, "1", lookup.Strict())
+			tt.check(t, err)
+			assert.Equal(t, newObj(), o)
+		})
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		o := newObj()
+
+		_, err := lookup.Insert(o, "Items[1]", "b", lookup.Strict())
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"a", "b"}, o.Items)
+		}
+
+		_, err = lookup.Insert(o, "Items[3]", "c", lookup.Strict())
+		var e *lookup.IndexOutOfRangeError
+		assert.ErrorAs(t, err, &e)
+	})
+}
+
+func TestLimits(t *testing.T) {
+	type item struct {
+		Next *item
+		Tags []string
+	}
+
+	type obj struct {
+		Items []string
+		Map   map[string]*item
+		Item  *item
+	}
+
+	tests := []struct {
+		name   string
+		limits lookup.Limits
+		path   string
+		limit  string
+		ok     bool
+	}{
+		{"path length", lookup.Limits{MaxPathLength: 5}, "Items[0]", "path length", false},
+		{"path length ok", lookup.Limits{MaxPathLength: 8}, "Items[0]", "", true},
+		{"segments", lookup.Limits{MaxSegments: 3}, "Item.Next.Next.Tags[0]", "segments", false},
+		{"segments with keys", lookup.Limits{MaxSegments: 3}, `Map["a"].Tags[0]`, "segments", false},
+		{"segments ok", lookup.Limits{MaxSegments: 4}, `Map["a"].Tags[0]`, "", true},
+		{"growth", lookup.Limits{MaxGrowth: 10}, "Items[100000]", "growth", false},
+		{"growth ok", lookup.Limits{MaxGrowth: 10}, "Items[9]", "", true},
+		{"allocations", lookup.Limits{MaxAllocations: 3}, "Item.Next.Next.Tags", "allocations", false},
+		{"allocations ok", lookup.Limits{MaxAllocations: 4}, "Item.Next.Next.Tags", "", true},
+		{"allocations by growth", lookup.Limits{MaxAllocations: 5}, "Items[5]", "allocations", false},
+		{"allocations in maps", lookup.Limits{MaxAllocations: 2}, `Map["a"].Next`, "allocations", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &obj{}
+
+			_, err := lookup.Create(o, tt.path, lookup.WithLimits(tt.limits))
+			if tt.ok {
+				assert.NoError(t, err)
+				return
+			}
+
+			var e *lookup.LimitError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, tt.limit, e.Limit)
+			}
+
+			assert.Equal(t, &obj{}, o)
+		})
+	}
+
+	t.Run("growth is checked before the slice grows", func(t *testing.T) {
+		o := &obj{Items: []string{"a"}}
+
+		_, err := lookup.Set(o, "Items[100]", "b", lookup.WithLimits(lookup.Limits{MaxGrowth: 10}))
+		assert.ErrorContains(t, err, "growth limit exceeded: 100 > 10")
+		assert.Equal(t, []string{"a"}, o.Items)
+	})
+
+	t.Run("existing locations don't count", func(t *testing.T) {
+		o := &obj{Items: make([]string, 100)}
+
+		_, err := lookup.Set(o, "Items[99]", "b", lookup.WithLimits(lookup.Limits{MaxGrowth: 1, MaxAllocations: 1}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("nothing is allocated before a limit is exceeded", func(t *testing.T) {
+		type node struct {
+			A *node
+			B *node
+			C *node
+			D *node
+			E *node
+			L []int
+		}
+
+		d := &node{}
+
+		_, err := lookup.Create(d, "A.B.C.D.E", lookup.WithLimits(lookup.Limits{MaxAllocations: 2}))
+		assert.ErrorContains(t, err, "allocations limit exceeded: 3 > 2")
+		assert.Equal(t, &node{}, d)
+
+		_, err = lookup.Set(d, "A.L[50]", 1, lookup.WithLimits(lookup.Limits{MaxGrowth: 10}))
+		assert.ErrorContains(t, err, "growth limit exceeded: 51 > 10")
+		assert.Equal(t, &node{}, d)
+	})
+
+	t.Run("existing values are changed in place", func(t *testing.T) {
+		o := &obj{Item: &item{}}
+		i := o.Item
+
+		_, err := lookup.Create(o, "Item.Next.Tags[1]", lookup.WithLimits(lookup.Limits{MaxAllocations: 3}))
+		require.NoError(t, err)
+		assert.Same(t, i, o.Item)
+		assert.Equal(t, []string{"", ""}, i.Next.Tags)
+	})
+}
+
+func TestOverflow(t *testing.T) {
+	type obj struct {
+		Port   uint16
+		Count  int8
+		Size   uint
+		Ratio  float32
+		Delay  time.Duration
+		Values []uint8
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		value  any
+		reason string
+	}{
+		{"overflow", "Port", int64(70000), lookup.Overflow},
+		{"negative overflow", "Count", -200, lookup.Overflow},
+		{"unsigned overflow", "Count", uint64(math.MaxUint64), lookup.Overflow},
+		{"sign loss", "Size", -1, lookup.SignLoss},
+		{"float sign loss", "Size", -2.0, lookup.SignLoss},
+		{"truncation", "Count", 1.5, lookup.Truncation},
+		{"not a number", "Count", math.NaN(), lookup.Truncation},
+		{"float overflow", "Port", 1e10, lookup.Overflow},
+		{"float32 overflow", "Ratio", math.MaxFloat64, lookup.Overflow},
+		{"named type", "Delay", uint64(math.MaxUint64), lookup.Overflow},
+		{"slice element", "Values[0]", 256, lookup.Overflow},
+		{"pointer", "Port", func() *int64 { v := int64(-1); return &v }(), lookup.SignLoss},
+		{"string overflow", "Port", "70000", lookup.Overflow},
+		{"string sign loss", "Size", "-1", lookup.SignLoss},
+		{"string truncation", "Count", "1.5", lookup.Truncation},
+		{"string float overflow", "Ratio", "1e300", lookup.Overflow},
+		{"string slice element", "Values[0]", "256", lookup.Overflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &obj{Values: []uint8{1}}
+
+			_, err := lookup.Set(o, tt.path, tt.value)
+
+			var e *lookup.OverflowError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, tt.reason, e.Reason)
+			}
+
+			assert.ErrorIs(t, err, lookup.ErrTypeMismatch)
+			assert.Equal(t, &obj{Values: []uint8{1}}, o)
+
+			_, err = lookup.Set(o, tt.path, tt.value, lookup.Lenient())
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("string", func(t *testing.T) {
+		o := &obj{}
+
+		_, err := lookup.Set(o, "Port", "70000")
+		assert.ErrorIs(t, err, strconv.ErrRange)
+		assert.ErrorContains(t, err, "value out of range")
+
+		_, err = lookup.Set(o, "Port", "70000", lookup.Lenient())
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(70000&0xffff), o.Port)
+
+		_, err = lookup.Set(o, "Count", "abc", lookup.Lenient())
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	})
+
+	t.Run("fits", func(t *testing.T) {
+		o := &obj{}
+
+		for path, value := range map[string]any{
+			"Port":  int64(65535),
+			"Count": -128.0,
+			"Size":  uint8(1),
+			"Ratio": 0.1,
+			"Delay": 5,
+		} {
+			_, err := lookup.Set(o, path, value)
+			assert.NoError(t, err, path)
+		}
+
+		assert.Equal(t, &obj{Port: 65535, Count: -128, Size: 1, Ratio: 0.1, Delay: 5}, o)
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		o := &obj{Values: []uint8{1}}
+
+		_, err := lookup.Insert(o, "Values[0]", -1)
+
+		var e *lookup.OverflowError
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, []uint8{1}, o.Values)
+	})
+}
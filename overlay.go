@@ -0,0 +1,286 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"reflect"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// Merge overlays src on the struct pointer dst, which have to be of the same
+// struct type. Non-zero fields of src overwrite the ones of dst, nested
+// structs are merged field by field. Slices are replaced and maps merged
+// unless WithSliceStrategy or WithMapStrategy set another strategy for
+// their path.
+//
+// The values are written with Set, so missing intermediates are created
+// with their defaults like Create does and protected fields are respected.
+// Merge is atomic like ApplyJSONPatch.
+func Merge(dst any, src any, opts ...Option) (err error) {
+	defer catch(&err)
+
+	vd := reflect.ValueOf(dst)
+	vs := reflect.ValueOf(src)
+
+	if !utils.IsStruct(vd) {
+		return notStruct("merge", valueType(vd))
+	}
+
+	if !utils.IsPointer(vd) {
+		return notPointer("merge", valueType(vd))
+	}
+
+	if !utils.IsStruct(vs) {
+		return notStruct("merge", valueType(vs))
+	}
+
+	td := vd.Type().Elem()
+	ts := reflect.Indirect(vs).Type()
+
+	if td != ts {
+		return &PathError{Op: "merge", Index: -1, Type: ts, Err: kindf(ErrTypeMismatch, "can't merge %v into %v", ts, td)}
+	}
+
+	vs = reflect.Indirect(vs)
+	if !vs.IsValid() || vd.IsNil() {
+		return nil
+	}
+
+	c, err := clone(dst)
+	if err != nil {
+		return err
+	}
+
+	o := &overlay{
+		options: newOptions(opts...),
+		dst:     c,
+		opts:    opts,
+		active:  map[reference]bool{},
+	}
+
+	if err := o.fields("", vs); err != nil {
+		return err
+	}
+
+	vd.Elem().Set(reflect.ValueOf(c).Elem())
+
+	return nil
+}
+
+type overlay struct {
+	options
+
+	dst    any
+	opts   []Option
+	active map[reference]bool // pointers of src on the current path
+}
+
+// fields merges the non-zero fields of the struct src into path.
+func (o *overlay) fields(path Path, src reflect.Value) error {
+	t := src.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || src.Field(i).IsZero() {
+			continue
+		}
+
+		if err := o.merge(path.Field(field.Name), src.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// merge merges the value src into the location path of dst. Pointers that
+// are already merged on the current path aren't followed again.
+func (o *overlay) merge(path Path, src reflect.Value) error {
+	if src.Kind() == reflect.Pointer && !src.IsNil() {
+		ref := reference{src.Pointer(), src.Type(), 0}
+		if o.active[ref] {
+			return nil
+		}
+
+		o.active[ref] = true
+		defer delete(o.active, ref)
+	}
+
+	if s, ok := record(src); ok {
+		if _, err := write("merge", o.dst, string(path), create, nil, o.opts...); err != nil {
+			return err
+		}
+
+		return o.fields(path, s)
+	}
+
+	switch src.Kind() {
+	case reflect.Slice:
+		return o.mergeSlice(path, src)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if src.Index(i).IsZero() {
+				continue
+			}
+
+			if err := o.merge(path.Index(i), src.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		if o.mapStrategy(path) == ReplaceMap {
+			return o.set(path, src)
+		}
+
+		for _, k := range sortedKeys(src) {
+			if err := o.merge(path.Key(k.Interface()), src.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return o.set(path, src)
+	}
+}
+
+// mergeSlice merges the slice src into path using the slice strategy.
+func (o *overlay) mergeSlice(path Path, src reflect.Value) error {
+	switch o.sliceStrategy(path) {
+	case AppendSlice:
+		for i := 0; i < src.Len(); i++ {
+			if err := o.append(path, src.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case MergeSlice:
+		current, err := newWalk("merge", exists, nil, o.opts...).run(reflect.ValueOf(o.dst), string(path))
+		if err != nil {
+			return err
+		}
+
+		cur := reflect.ValueOf(current)
+		if !cur.IsValid() || cur.Kind() != reflect.Slice {
+			cur = reflect.MakeSlice(src.Type(), 0, 0)
+		}
+
+		field, keyed := o.keyField(path, src.Type())
+		used := map[int]bool{}
+
+		for i := 0; i < src.Len(); i++ {
+			e := src.Index(i)
+			j := -1
+
+			if keyed {
+				if k, ok := keyOf(e, field); ok {
+					for n := 0; n < cur.Len(); n++ {
+						if c, ok := keyOf(cur.Index(n), field); ok && !used[n] && c == k {
+							j = n
+							break
+						}
+					}
+				}
+			} else if i < cur.Len() {
+				j = i
+			}
+
+			if j < 0 {
+				err = o.append(path, e)
+			} else {
+				used[j] = true
+
+				if !e.IsZero() {
+					err = o.merge(path.Index(j), e)
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return o.set(path, src)
+	}
+}
+
+// set writes a copy of v to path.
+func (o *overlay) set(path Path, v reflect.Value) error {
+	val, err := clone(v.Interface())
+	if err != nil {
+		return err
+	}
+
+	_, err = write("merge", o.dst, string(path), set, val, o.opts...)
+	return err
+}
+
+// append appends a copy of v to the slice at path.
+func (o *overlay) append(path Path, v reflect.Value) error {
+	val, err := clone(v.Interface())
+	if err != nil {
+		return err
+	}
+
+	_, err = write("merge", o.dst, string(path+"[]"), insert, val, o.opts...)
+	return err
+}
+
+func (o *overlay) sliceStrategy(path Path) SliceStrategy {
+	elements := normalize(string(path))
+	result := ReplaceSlice
+
+	for _, r := range o.slices {
+		if matches(r.pattern, elements) {
+			result = r.strategy
+		}
+	}
+
+	return result
+}
+
+func (o *overlay) mapStrategy(path Path) MapStrategy {
+	elements := normalize(string(path))
+	result := MergeMap
+
+	for _, r := range o.maps {
+		if matches(r.pattern, elements) {
+			result = r.strategy
+		}
+	}
+
+	return result
+}
+
+// record returns the struct behind v if it has exported fields to merge.
+// Structs like time.Time without exported fields are merged as a whole.
+func record(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() {
+			return v, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
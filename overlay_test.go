@@ -0,0 +1,181 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type mergeServer struct {
+	Name string
+	Port int `default:"80"`
+	Tags []string
+}
+
+type mergeObj struct {
+	Name    string
+	Enabled *bool
+	Created time.Time
+	Server  *mergeServer
+	Servers []mergeServer
+	Tags    []string
+	Labels  map[string]string
+	Entries map[string]mergeServer
+	ID      string `lookup:"readonly"`
+}
+
+func newMergeDst() *mergeObj {
+	return &mergeObj{
+		Name:    "base",
+		Servers: []mergeServer{{Name: "a", Port: 1}, {Name: "b", Port: 2}},
+		Tags:    []string{"x"},
+		Labels:  map[string]string{"app": "web", "tier": "db"},
+		Entries: map[string]mergeServer{"a": {Name: "a", Port: 1}},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	enabled := false
+
+	tests := []struct {
+		name   string
+		src    *mergeObj
+		opts   []lookup.Option
+		modify func(o *mergeObj)
+	}{
+		{"zero", &mergeObj{}, nil, func(o *mergeObj) {}},
+		{"fields", &mergeObj{Name: "x", Enabled: &enabled, Created: created}, nil, func(o *mergeObj) {
+			o.Name = "x"
+			o.Enabled = &enabled
+			o.Created = created
+		}},
+		{"create with defaults", &mergeObj{Server: &mergeServer{Name: "s"}}, nil, func(o *mergeObj) {
+			o.Server = &mergeServer{Name: "s", Port: 80}
+		}},
+		{"replace slice", &mergeObj{Tags: []string{"y"}, Servers: []mergeServer{{Name: "c"}}}, nil, func(o *mergeObj) {
+			o.Tags = []string{"y"}
+			o.Servers = []mergeServer{{Name: "c"}}
+		}},
+		{
+			"append slice",
+			&mergeObj{Tags: []string{"y"}},
+			[]lookup.Option{lookup.WithSliceStrategy("**", lookup.AppendSlice)},
+			func(o *mergeObj) {
+				o.Tags = []string{"x", "y"}
+			},
+		},
+		{
+			"merge slice by index",
+			&mergeObj{Servers: []mergeServer{{}, {Port: 3}, {Name: "c"}}},
+			[]lookup.Option{lookup.WithSliceStrategy("Servers", lookup.MergeSlice)},
+			func(o *mergeObj) {
+				o.Servers = []mergeServer{{Name: "a", Port: 1}, {Name: "b", Port: 3}, {Name: "c"}}
+			},
+		},
+		{
+			"merge slice by key",
+			&mergeObj{Servers: []mergeServer{{Name: "c", Port: 4}, {Name: "b", Port: 3, Tags: []string{"t"}}}},
+			[]lookup.Option{
+				lookup.WithSliceStrategy("**", lookup.MergeSlice),
+				lookup.WithSliceStrategy("**.Tags", lookup.AppendSlice),
+				lookup.MatchBy("Servers", "name"),
+			},
+			func(o *mergeObj) {
+				o.Servers = []mergeServer{{Name: "a", Port: 1}, {Name: "b", Port: 3, Tags: []string{"t"}}, {Name: "c", Port: 4}}
+			},
+		},
+		{"merge map", &mergeObj{Labels: map[string]string{"tier": "", "env": "prod"}, Entries: map[string]mergeServer{"a": {Port: 2}, "b": {Name: "b"}}}, nil, func(o *mergeObj) {
+			o.Labels = map[string]string{"app": "web", "tier": "", "env": "prod"}
+			o.Entries = map[string]mergeServer{"a": {Name: "a", Port: 2}, "b": {Name: "b", Port: 80}}
+		}},
+		{
+			"replace map",
+			&mergeObj{Labels: map[string]string{"env": "prod"}},
+			[]lookup.Option{lookup.WithMapStrategy("Labels", lookup.ReplaceMap)},
+			func(o *mergeObj) {
+				o.Labels = map[string]string{"env": "prod"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newMergeDst()
+			err := lookup.Merge(o, tt.src, tt.opts...)
+			require.NoError(t, err)
+
+			expected := newMergeDst()
+			tt.modify(expected)
+			assert.Equal(t, expected, o)
+		})
+	}
+}
+
+func TestMerge_Copy(t *testing.T) {
+	src := &mergeObj{Tags: []string{"y"}, Server: &mergeServer{Tags: []string{"t"}}}
+	o := newMergeDst()
+
+	err := lookup.Merge(o, src)
+	require.NoError(t, err)
+
+	src.Tags[0] = "z"
+	src.Server.Tags[0] = "z"
+
+	assert.Equal(t, []string{"y"}, o.Tags)
+	assert.Equal(t, []string{"t"}, o.Server.Tags)
+}
+
+func TestMerge_Error(t *testing.T) {
+	o := newMergeDst()
+
+	err := lookup.Merge(o, &mergeObj{Name: "x", ID: "1"})
+	assert.ErrorIs(t, err, lookup.ErrReadOnly)
+	assert.Equal(t, newMergeDst(), o)
+
+	err = lookup.Merge(o, &mergeServer{})
+	assert.ErrorIs(t, err, lookup.ErrTypeMismatch)
+
+	err = lookup.Merge(*o, &mergeObj{})
+	assert.Error(t, err)
+
+	err = lookup.Merge(o, 1)
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+}
+
+func TestMerge_Cycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	type obj struct {
+		Name string
+		N    *node
+	}
+
+	n := &node{Name: "a"}
+	n.Next = n
+	o := &obj{N: n}
+
+	err := lookup.Merge(o, &obj{Name: "y"})
+	require.NoError(t, err)
+	assert.Equal(t, "y", o.Name)
+	assert.Same(t, o.N, o.N.Next)
+
+	m := &node{Name: "b"}
+	m.Next = m
+
+	err = lookup.Merge(o, &obj{N: m})
+	require.NoError(t, err)
+	assert.Equal(t, "b", o.N.Name)
+	assert.Equal(t, "b", o.N.Next.Name)
+}
@@ -72,7 +72,9 @@ func Split(txt string, sep rune) []string {
 	return result
 }
 
-func Parse(txt string, t reflect.Type, hooks ...ParserHook) (any, error) {
+func Parse(txt string, t reflect.Type, hooks ...ParserHook) (result any, err error) {
+	defer catch(&err)
+
 	for _, hook := range hooks {
 		if hook.To == t {
 			return hook.Parse(txt)
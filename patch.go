@@ -0,0 +1,198 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+// operation is a single operation of a JSON Patch document.
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch applies the JSON Patch document (RFC 6902) to the struct
+// pointer obj. The paths are JSON Pointers resolved against the type of obj
+// and the values are decoded into the type of their location, so strings are
+// parsed like Set does. Missing intermediates of add are created, but an
+// index of add must not be beyond the end of the slice, and test never
+// changes obj.
+//
+// The patch is atomic: it is applied to a copy of obj, which replaces obj
+// only if all operations succeed.
+func ApplyJSONPatch(obj any, patch []byte, opts ...Option) (err error) {
+	defer catch(&err)
+
+	v := reflect.ValueOf(obj)
+
+	if !utils.IsStruct(v) {
+		return notStruct("patch", valueType(v))
+	}
+
+	if !utils.IsPointer(v) {
+		return notPointer("patch", valueType(v))
+	}
+
+	var ops []operation
+
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return &PathError{Op: "patch", Index: -1, Err: err}
+	}
+
+	c, err := clone(obj)
+	if err != nil {
+		return err
+	}
+
+	for i, op := range ops {
+		if err := applyOperation(c, op, opts...); err != nil {
+			return fmt.Errorf("operation %v: %w", i, err)
+		}
+	}
+
+	v.Elem().Set(reflect.ValueOf(c).Elem())
+
+	return nil
+}
+
+// applyOperation applies a single JSON Patch operation to the struct pointer
+// obj.
+func applyOperation(obj any, op operation, opts ...Option) error {
+	t := reflect.TypeOf(obj)
+	path := string(fromPointer(t, op.Path))
+	from := string(fromPointer(t, op.From))
+
+	switch op.Op {
+	case "add", "replace", "test":
+		val, err := decodeValue(t, op.Op, path, op.Value)
+		if err != nil {
+			return err
+		}
+
+		switch op.Op {
+		case "add":
+			if err = checkIndex(obj, op.Path, opts...); err == nil {
+				_, err = write(op.Op, obj, path, add, val, opts...)
+			}
+		case "replace":
+			if _, err = fetch(op.Op, obj, path, opts...); err == nil {
+				_, err = write(op.Op, obj, path, set, val, opts...)
+			}
+		case "test":
+			var current any
+
+			// exists mode doesn't create missing locations
+			current, err = newWalk(op.Op, exists, nil, opts...).run(reflect.ValueOf(obj), path)
+			if err == nil && (current != nil || !utils.IsNil(val)) && !reflect.DeepEqual(current, val) {
+				err = &PathError{Op: op.Op, Path: path, Index: -1, Err: fmt.Errorf("%w: %v != %v", ErrTestFailed, current, val)}
+			}
+		}
+
+		return err
+	case "remove":
+		if _, err := fetch(op.Op, obj, path, opts...); err != nil {
+			return err
+		}
+
+		_, err := write(op.Op, obj, path, remove, nil, opts...)
+		return err
+	case "move":
+		return MoveTo(obj, from, obj, path, opts...)
+	case "copy":
+		return CopyTo(obj, from, obj, path, opts...)
+	default:
+		return &PathError{Op: "patch", Path: path, Index: -1, Err: fmt.Errorf("unsupported operation: %q", op.Op)}
+	}
+}
+
+// checkIndex fails if the last token of the JSON Pointer is an index beyond
+// the end of a slice, which add doesn't allow.
+func checkIndex(obj any, pointer string, opts ...Option) error {
+	i := strings.LastIndexByte(pointer, '/')
+	if i < 0 {
+		return nil
+	}
+
+	t := reflect.TypeOf(obj)
+	parent := string(fromPointer(t, pointer[:i]))
+
+	pt, err := TypeAt(t, parent)
+	if err != nil {
+		// reported by add
+		return nil
+	}
+
+	for pt.Kind() == reflect.Pointer {
+		pt = pt.Elem()
+	}
+
+	idx, err := strconv.Atoi(pointerUnescaper.Replace(pointer[i+1:]))
+	if pt.Kind() != reflect.Slice || err != nil {
+		return nil
+	}
+
+	current, err := newWalk("add", exists, nil, opts...).run(reflect.ValueOf(obj), parent)
+	if err != nil {
+		return err
+	}
+
+	n := 0
+	if v := reflect.Indirect(reflect.ValueOf(current)); v.Kind() == reflect.Slice {
+		n = v.Len()
+	}
+
+	if idx > n {
+		path := string(fromPointer(t, pointer))
+		return &PathError{Op: "add", Path: path, Index: -1, Err: &IndexOutOfRangeError{Path: path, Index: idx, Len: n}}
+	}
+
+	return nil
+}
+
+// decodeValue decodes the JSON value raw into the type of the location path
+// in t. JSON strings that can't be decoded directly are parsed.
+func decodeValue(t reflect.Type, op string, path string, raw json.RawMessage) (any, error) {
+	if raw == nil {
+		return nil, &PathError{Op: op, Path: path, Index: -1, Err: &MissingValueError{path}}
+	}
+
+	typ, err := TypeAt(t, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(typ, op, path, raw)
+}
+
+// decode decodes the JSON value raw into the type typ of the location path.
+func decode(typ reflect.Type, op string, path string, raw json.RawMessage) (any, error) {
+	v := reflect.New(typ)
+
+	err := json.Unmarshal(raw, v.Interface())
+	if err == nil {
+		return v.Elem().Interface(), nil
+	}
+
+	var txt string
+	if bytes.HasPrefix(raw, []byte(`"`)) && json.Unmarshal(raw, &txt) == nil {
+		val, perr := Parse(txt, typ)
+		if perr == nil {
+			return val, nil
+		}
+	}
+
+	return nil, &PathError{Op: op, Path: path, Index: -1, Type: typ, Err: &kindError{ErrTypeMismatch, err}}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type patchServer struct {
+	Host string `json:"host"`
+	Port uint16 `json:"port"`
+}
+
+type patchObj struct {
+	Name    string            `json:"name"`
+	Timeout time.Duration     `json:"timeout"`
+	Server  *patchServer      `json:"server"`
+	Servers []patchServer     `json:"servers"`
+	Labels  map[string]string `json:"labels"`
+	ID      string            `json:"id" lookup:"readonly"`
+}
+
+func newPatchObj() *patchObj {
+	return &patchObj{
+		Name:    "x",
+		Timeout: time.Second,
+		Servers: []patchServer{{"a", 1}, {"b", 2}},
+		Labels:  map[string]string{"app/name": "web"},
+		ID:      "1",
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		patch  string
+		modify func(o *patchObj)
+	}{
+		{"replace", `[{"op": "replace", "path": "/name", "value": "y"}]`, func(o *patchObj) {
+			o.Name = "y"
+		}},
+		{"parse duration", `[{"op": "replace", "path": "/timeout", "value": "1m"}]`, func(o *patchObj) {
+			o.Timeout = time.Minute
+		}},
+		{"add nested", `[{"op": "add", "path": "/server/port", "value": 8080}]`, func(o *patchObj) {
+			o.Server = &patchServer{Port: 8080}
+		}},
+		{"add object", `[{"op": "add", "path": "/server", "value": {"host": "h", "port": 1}}]`, func(o *patchObj) {
+			o.Server = &patchServer{"h", 1}
+		}},
+		{"insert", `[{"op": "add", "path": "/servers/1", "value": {"host": "c"}}]`, func(o *patchObj) {
+			o.Servers = []patchServer{{"a", 1}, {"c", 0}, {"b", 2}}
+		}},
+		{"append", `[{"op": "add", "path": "/servers/-", "value": {"host": "c"}}]`, func(o *patchObj) {
+			o.Servers = append(o.Servers, patchServer{Host: "c"})
+		}},
+		{"escaped key", `[{"op": "add", "path": "/labels/app~1name", "value": "api"}]`, func(o *patchObj) {
+			o.Labels["app/name"] = "api"
+		}},
+		{"remove", `[{"op": "remove", "path": "/servers/0"}, {"op": "remove", "path": "/labels/app~1name"}]`, func(o *patchObj) {
+			o.Servers = []patchServer{{"b", 2}}
+			o.Labels = map[string]string{}
+		}},
+		{"move", `[{"op": "move", "from": "/servers/0/host", "path": "/name"}]`, func(o *patchObj) {
+			o.Name = "a"
+			o.Servers[0].Host = ""
+		}},
+		{"copy", `[{"op": "copy", "from": "/servers/1", "path": "/servers/0"}]`, func(o *patchObj) {
+			o.Servers = []patchServer{{"b", 2}, {"a", 1}, {"b", 2}}
+		}},
+		{"test", `[{"op": "test", "path": "/servers/1/port", "value": 2}, {"op": "test", "path": "/timeout", "value": "1s"}]`, func(o *patchObj) {}},
+		{"test null", `[{"op": "test", "path": "/server", "value": null}]`, func(o *patchObj) {}},
+		{"add at the end", `[{"op": "add", "path": "/servers/2", "value": {"host": "c"}}]`, func(o *patchObj) {
+			o.Servers = append(o.Servers, patchServer{Host: "c"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newPatchObj()
+			err := lookup.ApplyJSONPatch(o, []byte(tt.patch))
+			require.NoError(t, err)
+
+			expected := newPatchObj()
+			tt.modify(expected)
+			assert.Equal(t, expected, o)
+		})
+	}
+}
+
+func TestApplyJSONPatch_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		err   error
+	}{
+		{"test failed", `[{"op": "replace", "path": "/name", "value": "y"}, {"op": "test", "path": "/name", "value": "z"}]`, lookup.ErrTestFailed},
+		{"overflow", `[{"op": "replace", "path": "/name", "value": "y"}, {"op": "add", "path": "/servers/0/port", "value": 70000}]`, lookup.ErrTypeMismatch},
+		{"read only", `[{"op": "add", "path": "/name", "value": "y"}, {"op": "replace", "path": "/id", "value": "2"}]`, lookup.ErrReadOnly},
+		{"missing", `[{"op": "remove", "path": "/server/host"}]`, nil},
+		{"test missing key", `[{"op": "test", "path": "/labels/missing", "value": ""}]`, lookup.ErrTestFailed},
+		{"test missing element", `[{"op": "test", "path": "/servers/5/port", "value": 0}]`, lookup.ErrTestFailed},
+		{"add beyond the end", `[{"op": "add", "path": "/servers/3", "value": {"host": "c"}}]`, nil},
+		{"unknown field", `[{"op": "add", "path": "/nme", "value": "y"}]`, nil},
+		{"missing value", `[{"op": "add", "path": "/name"}]`, nil},
+		{"unsupported", `[{"op": "patch", "path": "/name"}]`, nil},
+		{"invalid", `{}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newPatchObj()
+			err := lookup.ApplyJSONPatch(o, []byte(tt.patch))
+			require.Error(t, err)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+
+			assert.Equal(t, newPatchObj(), o)
+		})
+	}
+
+	err := lookup.ApplyJSONPatch(patchObj{}, []byte(`[]`))
+	assert.Error(t, err)
+
+	err = lookup.ApplyJSONPatch(newPatchObj(), []byte(`[{"op": "add", "path": "/servers/3", "value": {}}]`))
+	var e *lookup.IndexOutOfRangeError
+	assert.ErrorAs(t, err, &e)
+}
+
+type patchNode struct {
+	Name string
+	Next *patchNode
+}
+
+type patchCycle struct {
+	Name  string
+	N     *patchNode
+	Nodes []*patchNode
+}
+
+func newPatchCycle() *patchCycle {
+	n := &patchNode{Name: "n"}
+	n.Next = n
+
+	return &patchCycle{N: n, Nodes: []*patchNode{n}}
+}
+
+func TestApplyJSONPatch_Cycle(t *testing.T) {
+	o := newPatchCycle()
+	n := o.N
+
+	err := lookup.ApplyJSONPatch(o, []byte(`[{"op": "replace", "path": "/Name", "value": "y"}, {"op": "replace", "path": "/N/Next/Name", "value": "m"}]`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "y", o.Name)
+	assert.Equal(t, "m", o.N.Name)
+	assert.Same(t, o.N, o.N.Next)
+	assert.Same(t, o.N, o.Nodes[0])
+	assert.Equal(t, "n", n.Name)
+
+	err = lookup.ApplyJSONPatch(o, []byte(`[{"op": "replace", "path": "/N/Name", "value": "x"}, {"op": "test", "path": "/Name", "value": "z"}]`))
+	assert.ErrorIs(t, err, lookup.ErrTestFailed)
+	assert.Equal(t, "m", o.N.Name)
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"reflect"
+	"strings"
+)
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// toPointer converts a path to a JSON Pointer (RFC 6901).
+func toPointer(path string) string {
+	var sb strings.Builder
+
+	for _, part := range split(path) {
+		_, keys := segment(part)
+
+		sb.WriteString("/")
+		sb.WriteString(pointerEscaper.Replace(fieldName(part)))
+
+		for _, key := range keys {
+			sb.WriteString("/")
+			sb.WriteString(pointerEscaper.Replace(unquote(key)))
+		}
+	}
+
+	return sb.String()
+}
+
+// fromPointer converts the JSON Pointer to a path on the type t. Tokens are
+// struct fields matched by their `json` name or case insensitive, indexes of
+// slices and arrays with `-` for the end, or map keys. Tokens that can't be
+// resolved are kept as field names, so the operation on the path reports
+// the error.
+func fromPointer(t reflect.Type, pointer string) Path {
+	if pointer == "" {
+		return ""
+	}
+
+	var path Path
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = pointerUnescaper.Replace(token)
+
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		var kind reflect.Kind
+		if t != nil {
+			kind = t.Kind()
+		}
+
+		switch kind {
+		case reflect.Slice, reflect.Array:
+			if token == "-" {
+				token = ""
+			}
+
+			path += "[" + Path(token) + "]"
+			t = t.Elem()
+		case reflect.Map:
+			path = path.Key(token)
+			t = t.Elem()
+		case reflect.Struct:
+			name, typ, ok := jsonField(t, token)
+			if !ok {
+				path = path.Field(token)
+				t = nil
+
+				continue
+			}
+
+			path = path.Field(string(name))
+			t = typ
+		default:
+			path = path.Field(token)
+			t = nil
+		}
+	}
+
+	return path
+}
+
+// jsonField returns the path below the struct type t and the type of the
+// exported field with the `json` name or the case insensitive Go name. Like
+// encoding/json, the fields of embedded structs without a name in the `json`
+// tag are searched as well, after the fields of t.
+func jsonField(t reflect.Type, name string) (Path, reflect.Type, bool) {
+	var match *reflect.StructField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || inlined(field) {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag != "" && tag != "-" && tag == name {
+			return Path(field.Name), field.Type, true
+		}
+
+		if tag != "-" && match == nil && strings.EqualFold(field.Name, name) {
+			match = &field
+		}
+	}
+
+	if match != nil {
+		return Path(match.Name), match.Type, true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || !inlined(field) {
+			continue
+		}
+
+		if path, typ, ok := jsonField(indirectType(field.Type), name); ok {
+			return Path(field.Name).Field(string(path)), typ, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// inlined reports whether encoding/json inlines the fields of the embedded
+// struct field.
+func inlined(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return field.Anonymous && name == "" && indirectType(field.Type).Kind() == reflect.Struct
+}
+
+// indirectType returns the type t points to, following all pointers.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// readonly fields can be read, but not written or deleted
+	readonly = "readonly"
+	// immutable fields can be written only while they are zero
+	immutable = "immutable"
+)
+
+// hasOption reports whether the `lookup` tag of field contains option.
+func hasOption(field reflect.StructField, option string) bool {
+	tag, ok := field.Tag.Lookup("lookup")
+	if !ok {
+		return false
+	}
+
+	for o := range strings.SplitSeq(tag, ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// locked returns an error if field with the value v on the path rejects
+// writes to itself and to everything below it.
+func locked(path string, field reflect.StructField, v reflect.Value) error {
+	switch {
+	case hasOption(field, readonly):
+		return fmt.Errorf("%w: %v", ErrReadOnly, path)
+	case hasOption(field, immutable) && !v.IsZero():
+		return fmt.Errorf("%w: %v", ErrImmutable, path)
+	default:
+		return nil
+	}
+}
+
+// protect checks a field on the path of a writing walk. Create only fails
+// once it has to allocate something below a locked field.
+func (w *walk) protect(field reflect.StructField, v reflect.Value) error {
+	if w.mode == get || w.mode == exists || w.locked != nil {
+		return nil
+	}
+
+	err := locked(w.path, field, v)
+	if w.mode == create {
+		w.locked = err
+		return nil
+	}
+
+	return err
+}
+
+// keep wraps set so replacing old fails if it changes a read only or
+// immutable field inside of old.
+func keep(path string, old reflect.Value, set func(value reflect.Value) error) func(value reflect.Value) error {
+	return func(value reflect.Value) error {
+		err := unchanged(path, old, value, map[reference]bool{})
+		if err != nil {
+			return err
+		}
+
+		return set(value)
+	}
+}
+
+// unchanged checks the locked fields inside of old against new, following
+// pointers, nested structs and the elements of slices, arrays and maps.
+// Elements are matched by index or key, removed elements aren't checked.
+func unchanged(path string, old reflect.Value, new reflect.Value, visited map[reference]bool) error {
+	for old.Kind() == reflect.Pointer || old.Kind() == reflect.Interface {
+		if old.IsNil() {
+			return nil
+		}
+
+		if old.Kind() == reflect.Pointer {
+			ref := reference{old.Pointer(), old.Type(), 0}
+			if visited[ref] {
+				return nil
+			}
+
+			visited[ref] = true
+		}
+
+		old = old.Elem()
+	}
+
+	for new.Kind() == reflect.Pointer || new.Kind() == reflect.Interface {
+		if new.IsNil() {
+			new = reflect.Zero(old.Type())
+			break
+		}
+
+		new = new.Elem()
+	}
+
+	if old.Type() != new.Type() {
+		return nil
+	}
+
+	switch old.Kind() {
+	case reflect.Slice, reflect.Map:
+		if old.IsNil() {
+			return nil
+		}
+
+		ref := reference{old.Pointer(), old.Type(), old.Len()}
+		if visited[ref] {
+			return nil
+		}
+
+		visited[ref] = true
+	}
+
+	switch old.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < min(old.Len(), new.Len()); i++ {
+			if err := unchanged(string(Path(path).Index(i)), old.Index(i), new.Index(i), visited); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		for _, k := range sortedKeys(old) {
+			n := new.MapIndex(k)
+			if !n.IsValid() {
+				continue
+			}
+
+			if err := unchanged(string(Path(path).Key(k.Interface())), old.MapIndex(k), n, visited); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if old.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := old.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		o := old.Field(i)
+		n := new.Field(i)
+		p := path + "." + field.Name
+
+		if err := locked(p, field, o); err != nil {
+			if !reflect.DeepEqual(o.Interface(), n.Interface()) {
+				return err
+			}
+
+			continue
+		}
+
+		if err := unchanged(p, o, n, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
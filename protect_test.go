@@ -0,0 +1,181 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestProtectedFields(t *testing.T) {
+	type meta struct {
+		ID      string    `lookup:"readonly"`
+		Created time.Time `lookup:"immutable"`
+		Labels  map[string]string
+	}
+
+	type item struct {
+		Meta meta
+		Name string
+	}
+
+	type obj struct {
+		Meta    meta
+		Owner   *meta `lookup:"immutable"`
+		Fixed   *item `lookup:"readonly"`
+		Items   []item
+		Entries map[string]item
+	}
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	newObj := func() *obj {
+		return &obj{
+			Meta:    meta{ID: "1", Created: created},
+			Owner:   &meta{ID: "2"},
+			Items:   []item{{Meta: meta{ID: "3"}}},
+			Entries: map[string]item{"a": {Meta: meta{ID: "4"}}},
+		}
+	}
+
+	tests := []struct {
+		name string
+		op   func(o *obj) error
+		err  error
+	}{
+		{"set read only", func(o *obj) error {
+			_, err := lookup.Set(o, "Meta.ID", "x")
+			return err
+		}, lookup.ErrReadOnly},
+		{"delete read only", func(o *obj) error {
+			_, err := lookup.Delete(o, "Meta.ID")
+			return err
+		}, lookup.ErrReadOnly},
+		{"set below read only", func(o *obj) error {
+			_, err := lookup.Set(o, "Fixed.Name", "x")
+			return err
+		}, lookup.ErrReadOnly},
+		{"create below read only", func(o *obj) error {
+			_, err := lookup.Create(o, "Fixed.Meta")
+			return err
+		}, lookup.ErrReadOnly},
+		{"set immutable", func(o *obj) error {
+			_, err := lookup.Set(o, "Meta.Created", time.Now())
+			return err
+		}, lookup.ErrImmutable},
+		{"delete immutable", func(o *obj) error {
+			_, err := lookup.Delete(o, "Meta.Created")
+			return err
+		}, lookup.ErrImmutable},
+		{"set below immutable", func(o *obj) error {
+			_, err := lookup.Set(o, `Owner.Labels["a"]`, "x")
+			return err
+		}, lookup.ErrImmutable},
+		{"replace parent", func(o *obj) error {
+			_, err := lookup.Set(o, "Meta", meta{ID: "x", Created: created})
+			return err
+		}, lookup.ErrReadOnly},
+		{"delete parent", func(o *obj) error {
+			_, err := lookup.Delete(o, "Meta")
+			return err
+		}, lookup.ErrReadOnly},
+		{"replace slice element", func(o *obj) error {
+			_, err := lookup.Set(o, "Items[0]", item{Name: "x"})
+			return err
+		}, lookup.ErrReadOnly},
+		{"replace map value", func(o *obj) error {
+			_, err := lookup.Set(o, `Entries["a"]`, item{Name: "x"})
+			return err
+		}, lookup.ErrReadOnly},
+		{"set in map value", func(o *obj) error {
+			_, err := lookup.Set(o, `Entries["a"].Meta.ID`, "x")
+			return err
+		}, lookup.ErrReadOnly},
+		{"replace slice", func(o *obj) error {
+			_, err := lookup.Set(o, "Items", []item{{Meta: meta{ID: "x"}}})
+			return err
+		}, lookup.ErrReadOnly},
+		{"replace map", func(o *obj) error {
+			_, err := lookup.Set(o, "Entries", map[string]item{"a": {Meta: meta{ID: "x"}}})
+			return err
+		}, lookup.ErrReadOnly},
+		{"move", func(o *obj) error {
+			return lookup.Move(o, "Meta.ID", `Meta.Labels["id"]`)
+		}, lookup.ErrReadOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newObj()
+
+			err := tt.op(o)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, newObj(), o)
+		})
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		o := newObj()
+
+		for path, value := range map[string]any{
+			`Meta.Labels["a"]`: "x",
+			"Items[0].Name":    "x",
+			"Items[1]":         item{Name: "y"},
+			`Entries["b"]`:     item{Meta: meta{ID: "5"}},
+			"Items[0]":         item{Meta: meta{ID: "3"}, Name: "z"},
+		} {
+			_, err := lookup.Set(o, path, value)
+			assert.NoError(t, err, path)
+		}
+
+		v, err := lookup.Get(o, "Meta.ID")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "1", v)
+		}
+
+		_, err = lookup.Set(o, "Items", []item{{Meta: meta{ID: "3"}, Name: "a"}, {Name: "b"}})
+		assert.NoError(t, err)
+
+		_, err = lookup.Set(o, "Entries", map[string]item{"a": {Meta: meta{ID: "4"}}, "c": {}})
+		assert.NoError(t, err)
+
+		_, err = lookup.Set(o, "Entries", map[string]item{"a": {Meta: meta{ID: "4"}, Name: "a"}})
+		assert.NoError(t, err)
+
+		ok, err := lookup.Delete(o, "Items[0]")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = lookup.Delete(o, `Entries["a"]`)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		_, err = lookup.Create(o, "Fixed")
+		assert.ErrorIs(t, err, lookup.ErrReadOnly)
+	})
+
+	t.Run("immutable while zero", func(t *testing.T) {
+		o := &obj{}
+
+		_, err := lookup.Set(o, "Meta.Created", created)
+		assert.NoError(t, err)
+
+		_, err = lookup.Set(o, "Owner", &meta{ID: "x"})
+		assert.NoError(t, err)
+
+		_, err = lookup.Set(o, "Owner", &meta{ID: "y"})
+		assert.ErrorIs(t, err, lookup.ErrImmutable)
+
+		_, err = lookup.Set(o, "Meta.Created", created.Add(time.Hour))
+		assert.ErrorIs(t, err, lookup.ErrImmutable)
+
+		assert.Equal(t, created, o.Meta.Created)
+		assert.Equal(t, "x", o.Owner.ID)
+	})
+}
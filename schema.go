@@ -0,0 +1,173 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Node describes a location of a type. Elements of slices and arrays are
+// named `[]`, values of maps `[key]`.
+type Node struct {
+	Name        string            // Go field name or placeholder
+	Path        string            // path with placeholders, e.g. `Servers[].Port`
+	Tags        map[string]string // names from tags like `json` or `yaml`
+	Type        reflect.Type
+	Kind        reflect.Kind // kind of Type without pointers
+	Key         reflect.Type // key type of maps
+	Elem        reflect.Type // element type of slices, arrays and maps
+	Default     string       // `default` tag
+	Description string       // `description` tag
+	Recursive   bool         // the type contains itself and isn't expanded again
+	Children    []*Node
+}
+
+// Schema returns the locations of the struct type t as a tree. The root node
+// has no name and contains the fields of t.
+func Schema(t reflect.Type) (*Node, error) {
+	s := t
+	for s != nil && s.Kind() == reflect.Pointer {
+		s = s.Elem()
+	}
+
+	if s == nil || s.Kind() != reflect.Struct {
+		return nil, notStruct("schema", t)
+	}
+
+	root := &Node{Type: t, Kind: reflect.Struct}
+	describe(root, map[reflect.Type]bool{})
+
+	return root, nil
+}
+
+// Paths returns the paths of all nodes below n in depth first order.
+func (n *Node) Paths() []string {
+	var result []string
+
+	for _, c := range n.Children {
+		result = append(result, c.Path)
+		result = append(result, c.Paths()...)
+	}
+
+	return result
+}
+
+// describe adds the children of n. active contains the types on the current
+// path.
+func describe(n *Node, active map[reflect.Type]bool) {
+	t := n.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	n.Kind = t.Kind()
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if active[t] {
+			n.Recursive = true
+			return
+		}
+
+		active[t] = true
+		defer delete(active, t)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			child := &Node{
+				Name:        field.Name,
+				Path:        string(Path(n.Path).Field(field.Name)),
+				Tags:        tagNames(field.Tag),
+				Type:        field.Type,
+				Default:     field.Tag.Get("default"),
+				Description: field.Tag.Get("description"),
+			}
+
+			describe(child, active)
+			n.Children = append(n.Children, child)
+		}
+	case reflect.Slice, reflect.Array:
+		n.Elem = t.Elem()
+		n.Children = []*Node{element(n, "[]", t.Elem(), active)}
+	case reflect.Map:
+		n.Key = t.Key()
+		n.Elem = t.Elem()
+		n.Children = []*Node{element(n, "[key]", t.Elem(), active)}
+	}
+}
+
+func element(parent *Node, name string, t reflect.Type, active map[reflect.Type]bool) *Node {
+	n := &Node{
+		Name: name,
+		Path: parent.Path + name,
+		Type: t,
+	}
+
+	describe(n, active)
+	return n
+}
+
+// tagNames returns the name part of all tags except of the ones with a
+// meaning for the schema itself.
+func tagNames(tag reflect.StructTag) map[string]string {
+	var result map[string]string
+
+	// same format as parsed by reflect.StructTag.Lookup
+	for tag != "" {
+		tag = reflect.StructTag(strings.TrimLeft(string(tag), " "))
+
+		i := strings.IndexByte(string(tag), ':')
+		if i <= 0 || i+1 >= len(tag) || tag[i+1] != '"' {
+			break
+		}
+
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		j := 1
+		for j < len(tag) && tag[j] != '"' {
+			if tag[j] == '\\' {
+				j++
+			}
+
+			j++
+		}
+
+		if j >= len(tag) {
+			break
+		}
+
+		value, err := strconv.Unquote(string(tag[:j+1]))
+		tag = tag[j+1:]
+
+		if err != nil {
+			break
+		}
+
+		switch key {
+		case "default", "description", "lookup":
+			continue
+		}
+
+		if result == nil {
+			result = map[string]string{}
+		}
+
+		name, _, _ := strings.Cut(value, ",")
+		result[key] = name
+	}
+
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type schemaTree struct {
+	Name     string
+	Children []schemaTree
+	Parent   *schemaTree
+}
+
+type schemaMap map[string]schemaMap
+
+func TestSchema(t *testing.T) {
+	type tls struct {
+		Cert string `json:"cert,omitempty" yaml:"cert" description:"certificate file"`
+	}
+
+	type server struct {
+		Host    string        `json:"host" default:"localhost"`
+		Port    uint16        `default:"8080" lookup:"readonly"`
+		Timeout time.Duration `description:"request timeout"`
+		TLS     *tls
+	}
+
+	type config struct {
+		Servers []server
+		Labels  map[string]string
+		Matrix  [2][]int
+		Tree    schemaTree
+		Nested  schemaMap
+		Any     any
+		private int
+	}
+
+	root, err := lookup.Schema(reflect.TypeFor[*config]())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Servers",
+		"Servers[]",
+		"Servers[].Host",
+		"Servers[].Port",
+		"Servers[].Timeout",
+		"Servers[].TLS",
+		"Servers[].TLS.Cert",
+		"Labels",
+		"Labels[key]",
+		"Matrix",
+		"Matrix[]",
+		"Matrix[][]",
+		"Tree",
+		"Tree.Name",
+		"Tree.Children",
+		"Tree.Children[]",
+		"Tree.Parent",
+		"Nested",
+		"Nested[key]",
+		"Any",
+	}, root.Paths())
+
+	servers := root.Children[0]
+	assert.Equal(t, reflect.Slice, servers.Kind)
+	assert.Equal(t, reflect.TypeFor[server](), servers.Elem)
+
+	elem := servers.Children[0]
+	assert.Equal(t, "[]", elem.Name)
+	assert.Equal(t, reflect.Struct, elem.Kind)
+
+	host := elem.Children[0]
+	assert.Equal(t, "Host", host.Name)
+	assert.Equal(t, map[string]string{"json": "host"}, host.Tags)
+	assert.Equal(t, "localhost", host.Default)
+
+	port := elem.Children[1]
+	assert.Nil(t, port.Tags)
+	assert.Equal(t, "8080", port.Default)
+
+	assert.Equal(t, "request timeout", elem.Children[2].Description)
+
+	tlsNode := elem.Children[3]
+	assert.Equal(t, reflect.TypeFor[*tls](), tlsNode.Type)
+	assert.Equal(t, reflect.Struct, tlsNode.Kind)
+
+	cert := tlsNode.Children[0]
+	assert.Equal(t, map[string]string{"json": "cert", "yaml": "cert"}, cert.Tags)
+	assert.Equal(t, "certificate file", cert.Description)
+
+	labels := root.Children[1]
+	assert.Equal(t, reflect.TypeFor[string](), labels.Key)
+	assert.Equal(t, "[key]", labels.Children[0].Name)
+
+	tree := root.Children[3]
+	assert.False(t, tree.Recursive)
+	assert.True(t, tree.Children[1].Children[0].Recursive)
+	assert.Empty(t, tree.Children[1].Children[0].Children)
+	assert.True(t, tree.Children[2].Recursive)
+
+	assert.True(t, root.Children[4].Children[0].Recursive)
+
+	_, err = lookup.Schema(reflect.TypeFor[int]())
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+
+	_, err = lookup.Schema(nil)
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// suggest returns the exported fields of the struct type t with a name
+// similar to the lower case name, closest first.
+func suggest(name string, t reflect.Type) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	// allow about one typo per three characters
+	limit := max(1, len(name)/3)
+
+	var candidates []candidate
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		d := distance(name, strings.ToLower(field.Name))
+		if d <= limit {
+			candidates = append(candidates, candidate{field.Name, d})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a candidate, b candidate) int {
+		return a.distance - b.distance
+	})
+
+	var result []string
+	for _, c := range candidates {
+		result = append(result, c.name)
+	}
+
+	return result
+}
+
+// distance returns the Damerau-Levenshtein distance (optimal string
+// alignment) between a and b, so swapped letters count as one edit.
+func distance(a string, b string) int {
+	s := []rune(a)
+	t := []rune(b)
+
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestSuggestions(t *testing.T) {
+	type server struct {
+		Host    string
+		Port    int
+		Ports   []int
+		Timeout int
+		secret  string
+	}
+
+	type obj struct {
+		Server server
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		suggestions []string
+		message     string
+	}{
+		{"typo", "Server.Hots", []string{"Host"}, "field not found: hots (did you mean Host?)"},
+		{"closest first", "Server.Portss", []string{"Ports", "Port"}, "field not found: portss (did you mean Ports, Port?)"},
+		{"missing letter", "Server.Timout", []string{"Timeout"}, "field not found: timout (did you mean Timeout?)"},
+		{"top level", "Sever.Host", []string{"Server"}, "field not found: sever (did you mean Server?)"},
+		{"unexported", "Server.secrte", nil, "field not found: secrte"},
+		{"nothing similar", "Server.Address", nil, "field not found: address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lookup.Get(&obj{}, tt.path)
+
+			var e *lookup.NotFoundError
+			if assert.ErrorAs(t, err, &e) {
+				assert.Equal(t, tt.suggestions, e.Suggestions)
+				assert.EqualError(t, e, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// TypeAt returns the type of the location path in the struct type t without
+// an instance. The path is resolved like Get does and fails with the same
+// PathError. Indexes are only checked against the length of arrays.
+func TypeAt(t reflect.Type, path string) (result reflect.Type, err error) {
+	w := newWalk("type at", get, nil)
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, w.fail(&PanicError{r})
+		}
+	}()
+
+	s := t
+	for s != nil && s.Kind() == reflect.Pointer {
+		s = s.Elem()
+	}
+
+	if s == nil || s.Kind() != reflect.Struct {
+		return nil, notStruct("type at", t)
+	}
+
+	result, err = w.typeAt(t, split(path)...)
+	if err != nil {
+		return nil, w.fail(err)
+	}
+
+	return result, nil
+}
+
+// ValidatePaths checks all paths against the struct type t with TypeAt and
+// returns the joined errors of all invalid paths.
+func ValidatePaths(t reflect.Type, paths ...string) error {
+	var errs []error
+
+	for _, path := range paths {
+		if _, err := TypeAt(t, path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// typeAt resolves the path segments on the type t like process does on a
+// value.
+func (w *walk) typeAt(t reflect.Type, path ...string) (reflect.Type, error) {
+	for _, part := range path {
+		fn, keys := segment(part)
+		name := fieldName(part)
+
+		w.enter(name, t)
+
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return nil, kindf(ErrNotStruct, "field isn't a struct")
+		}
+
+		fi, err := w.field(t, name, fn)
+		if err != nil {
+			return nil, err
+		}
+
+		t = t.Field(fi).Type
+
+		for _, key := range keys {
+			t, err = w.keyType(t, key)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// keyType returns the element type of the slice, array or map t for key.
+func (w *walk) keyType(t reflect.Type, key string) (reflect.Type, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	w.enter("["+key+"]", t)
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if key == "" {
+			if t.Kind() == reflect.Array {
+				return nil, kindf(ErrNotExpandable, "array isn't expandable")
+			}
+
+			return t.Elem(), nil
+		}
+
+		idx, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, ErrNotMap
+		}
+
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid index: %v", idx)
+		}
+
+		if t.Kind() == reflect.Array && idx >= t.Len() {
+			return nil, kindf(ErrNotExpandable, "array isn't expandable")
+		}
+
+		return t.Elem(), nil
+	case reflect.Map:
+		key = unquote(key)
+
+		if !reflect.TypeFor[string]().AssignableTo(t.Key()) {
+			if _, err := Parse(key, t.Key()); err != nil {
+				return nil, &kindError{ErrTypeMismatch, err}
+			}
+		}
+
+		return t.Elem(), nil
+	default:
+		return nil, ErrNotMap
+	}
+}
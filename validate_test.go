@@ -0,0 +1,120 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zauberhaus/lookup"
+)
+
+func TestTypeAt(t *testing.T) {
+	type tls struct {
+		Cert string
+	}
+
+	type server struct {
+		Port uint16
+		TLS  *tls
+	}
+
+	type config struct {
+		Servers []server
+		Hosts   map[string]*server
+		Ports   map[int]uint16
+		Pair    [2]string
+		Tags    *[]string
+		Groups  map[string][]string
+		Name    string
+		secret  string
+	}
+
+	typ := reflect.TypeFor[config]()
+
+	tests := []struct {
+		path     string
+		expected reflect.Type
+		err      error
+	}{
+		{"Servers[0].TLS.Cert", reflect.TypeFor[string](), nil},
+		{"servers[].tls", reflect.TypeFor[*tls](), nil},
+		{"Servers", reflect.TypeFor[[]server](), nil},
+		{`Hosts["a"].Port`, reflect.TypeFor[uint16](), nil},
+		{"Ports[80]", reflect.TypeFor[uint16](), nil},
+		{"Pair[1]", reflect.TypeFor[string](), nil},
+		{"Tags[3]", reflect.TypeFor[string](), nil},
+		{`Groups["admin"][0]`, reflect.TypeFor[string](), nil},
+		{"Pair[2]", nil, lookup.ErrNotExpandable},
+		{"Ports[http]", nil, lookup.ErrTypeMismatch},
+		{"Name.First", nil, lookup.ErrNotStruct},
+		{"Name[0]", nil, lookup.ErrNotMap},
+		{"Servers[a]", nil, lookup.ErrNotMap},
+		{"secret", nil, lookup.ErrUnexported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, err := lookup.TypeAt(typ, tt.path)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				var e *lookup.PathError
+				assert.ErrorAs(t, err, &e)
+				assert.Equal(t, "type at", e.Op)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("same error as get", func(t *testing.T) {
+		_, err := lookup.TypeAt(typ, "Servers[0].TLS.Crt")
+
+		var e *lookup.PathError
+		if assert.ErrorAs(t, err, &e) {
+			assert.Equal(t, "Servers[0].TLS.Crt", e.Path)
+			assert.Equal(t, "Crt", e.Segment)
+			assert.Equal(t, 3, e.Index)
+			assert.Equal(t, reflect.TypeFor[*tls](), e.Type)
+		}
+
+		var nf *lookup.NotFoundError
+		if assert.ErrorAs(t, err, &nf) {
+			assert.Equal(t, []string{"Cert"}, nf.Suggestions)
+		}
+
+		_, get := lookup.Get(&config{Servers: []server{{}}}, "Servers[0].TLS.Crt")
+		assert.Equal(t, errors.Unwrap(get).Error(), errors.Unwrap(err).Error())
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := lookup.TypeAt(reflect.TypeFor[int](), "Name")
+		assert.ErrorIs(t, err, lookup.ErrNotStruct)
+	})
+}
+
+func TestValidatePaths(t *testing.T) {
+	type config struct {
+		Name  string
+		Ports []int
+	}
+
+	typ := reflect.TypeFor[*config]()
+
+	assert.NoError(t, lookup.ValidatePaths(typ, "Name", "Ports[0]"))
+
+	err := lookup.ValidatePaths(typ, "Name", "Nmae", "Ports[a]", "Ports[1]", "Ports.Size")
+	assert.ErrorIs(t, err, lookup.ErrNotMap)
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+
+	var nf *lookup.NotFoundError
+	assert.ErrorAs(t, err, &nf)
+
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	utils "github.com/zauberhaus/reflect_utils"
+)
+
+var (
+	// SkipDir returned by a WalkFunc skips everything below the location.
+	SkipDir = errors.New("skip this location")
+	// SkipAll returned by a WalkFunc stops the walk without an error.
+	SkipAll = errors.New("skip everything")
+)
+
+// Path is a path in the syntax accepted by Get and Set.
+type Path string
+
+// Field returns the path of the field name below p.
+func (p Path) Field(name string) Path {
+	if p == "" {
+		return Path(name)
+	}
+
+	return p + "." + Path(name)
+}
+
+// Index returns the path of the element i below p.
+func (p Path) Index(i int) Path {
+	return p + "[" + Path(strconv.Itoa(i)) + "]"
+}
+
+// Key returns the path of the map entry key below p. String keys are quoted
+// with the first quote character they don't contain, or with an escaped
+// quote if they contain all of them.
+func (p Path) Key(key any) Path {
+	v := reflect.ValueOf(key)
+	if v.Kind() != reflect.String {
+		return p + "[" + Path(fmt.Sprint(key)) + "]"
+	}
+
+	quote := `\"`
+	for _, q := range []string{`"`, `'`, "`"} {
+		if !strings.Contains(v.String(), q) {
+			quote = q
+			break
+		}
+	}
+
+	return p + "[" + Path(quote+v.String()+quote) + "]"
+}
+
+// WalkFunc is called by Walk for every location. field is set for struct
+// fields and nil for elements of slices, arrays and maps.
+type WalkFunc func(path Path, v reflect.Value, field *reflect.StructField) error
+
+// Walk calls fn for every location reachable from the struct obj, parents
+// before their children. Fields are visited in declaration order, elements
+// by index and map entries sorted by key. Pointers are followed, values in
+// interfaces aren't. A location that is part of a reference cycle is visited,
+// but not walked again.
+//
+// If fn returns SkipDir, the children of the location are skipped, SkipAll
+// stops the walk. Any other error stops the walk and is returned.
+func Walk(obj any, fn WalkFunc) (err error) {
+	defer catch(&err)
+
+	v := reflect.ValueOf(obj)
+
+	if !utils.IsStruct(v) {
+		return notStruct("walk", valueType(v))
+	}
+
+	return newVisitor(fn, false).run(v)
+}
+
+func newVisitor(fn WalkFunc, leaves bool) *visitor {
+	return &visitor{
+		fn:     fn,
+		active: map[reference]bool{},
+		leaves: leaves,
+	}
+}
+
+func (w *visitor) run(v reflect.Value) error {
+	err := w.children("", v)
+	if err == SkipAll {
+		return nil
+	}
+
+	return err
+}
+
+// reference identifies a pointer, map or slice to detect cycles.
+type reference struct {
+	ptr uintptr
+	t   reflect.Type
+	len int
+}
+
+type visitor struct {
+	fn     WalkFunc
+	active map[reference]bool // references on the current path
+	leaves bool               // call fn only for locations without children
+	count  int                // visited locations
+}
+
+func (w *visitor) visit(path Path, v reflect.Value, field *reflect.StructField) error {
+	w.count++
+
+	if w.leaves {
+		n := w.count
+
+		err := w.children(path, v)
+		if err != nil || w.count > n {
+			return err
+		}
+
+		return w.fn(path, v, field)
+	}
+
+	err := w.fn(path, v, field)
+	if err == SkipDir {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return w.children(path, v)
+}
+
+// children visits the fields, elements or entries of v.
+func (w *visitor) children(path Path, v reflect.Value) error {
+	for {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice:
+			if v.IsNil() {
+				return nil
+			}
+
+			ref := reference{v.Pointer(), v.Type(), 0}
+			if v.Kind() == reflect.Slice {
+				ref.len = v.Len()
+			}
+
+			if w.active[ref] {
+				return nil
+			}
+
+			w.active[ref] = true
+			defer delete(w.active, ref)
+		}
+
+		if v.Kind() != reflect.Pointer {
+			break
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if err := w.visit(path.Field(field.Name), v.Field(i), &field); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := w.visit(path.Index(i), v.Index(i), nil); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, k := range sortedKeys(v) {
+			if err := w.visit(path.Key(k.Interface()), v.MapIndex(k), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of the map v in a deterministic order.
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+
+	slices.SortFunc(keys, func(a reflect.Value, b reflect.Value) int {
+		switch {
+		case isInt(a.Kind()):
+			return cmp.Compare(a.Int(), b.Int())
+		case isUint(a.Kind()):
+			return cmp.Compare(a.Uint(), b.Uint())
+		case isFloat(a.Kind()):
+			return cmp.Compare(a.Float(), b.Float())
+		case a.Kind() == reflect.String:
+			return strings.Compare(a.String(), b.String())
+		default:
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		}
+	})
+
+	return keys
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Zauberhaus
+// Licensed to Zauberhaus under one or more agreements.
+// Zauberhaus licenses this file to you under the Apache 2.0 License.
+// See the LICENSE file in the project root for more information.
+
+package lookup_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zauberhaus/lookup"
+)
+
+type node struct {
+	Name string
+	Next *node
+}
+
+type walkObj struct {
+	Name    string
+	Tags    []string
+	Array   [2]int
+	Map     map[string]int
+	Numbers map[int]string
+	Any     map[string]any
+	Nested  *node
+	Nil     *node
+	private string
+}
+
+func newWalkObj() *walkObj {
+	n := &node{Name: "a"}
+	n.Next = &node{Name: "b", Next: n}
+
+	return &walkObj{
+		Name:    "x",
+		Tags:    []string{"a", "b"},
+		Array:   [2]int{1, 2},
+		Map:     map[string]int{"b": 2, "a.b": 1, `"q"`: 3},
+		Numbers: map[int]string{10: "ten", 2: "two"},
+		Any:     map[string]any{"m": map[string]int{"x": 1}},
+		Nested:  n,
+	}
+}
+
+func TestWalk(t *testing.T) {
+	o := newWalkObj()
+
+	var paths []lookup.Path
+	var fields []string
+
+	err := lookup.Walk(o, func(path lookup.Path, v reflect.Value, field *reflect.StructField) error {
+		paths = append(paths, path)
+
+		if field != nil {
+			fields = append(fields, field.Name)
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []lookup.Path{
+		"Name",
+		"Tags", "Tags[0]", "Tags[1]",
+		"Array", "Array[0]", "Array[1]",
+		"Map", `Map['"q"']`, `Map["a.b"]`, `Map["b"]`,
+		"Numbers", "Numbers[2]", "Numbers[10]",
+		"Any", `Any["m"]`,
+		"Nested", "Nested.Name", "Nested.Next", "Nested.Next.Name", "Nested.Next.Next",
+		"Nil",
+	}, paths)
+
+	assert.Equal(t, []string{"Name", "Tags", "Array", "Map", "Numbers", "Any", "Nested", "Name", "Next", "Name", "Next", "Nil"}, fields)
+
+	for _, path := range paths {
+		var expected any
+
+		_ = lookup.Walk(o, func(p lookup.Path, v reflect.Value, field *reflect.StructField) error {
+			if p == path {
+				expected = v.Interface()
+				return lookup.SkipAll
+			}
+
+			return nil
+		})
+
+		val, err := lookup.Get(o, string(path))
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, expected, val, path)
+		}
+	}
+}
+
+func TestWalk_Skip(t *testing.T) {
+	o := newWalkObj()
+
+	var paths []lookup.Path
+
+	err := lookup.Walk(o, func(path lookup.Path, v reflect.Value, field *reflect.StructField) error {
+		paths = append(paths, path)
+
+		switch path {
+		case "Tags", "Map", "Numbers", "Any":
+			return lookup.SkipDir
+		case "Nested.Name":
+			return lookup.SkipAll
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []lookup.Path{"Name", "Tags", "Array", "Array[0]", "Array[1]", "Map", "Numbers", "Any", "Nested", "Nested.Name"}, paths)
+
+	failed := errors.New("failed")
+
+	err = lookup.Walk(o, func(path lookup.Path, v reflect.Value, field *reflect.StructField) error {
+		if path == "Array[1]" {
+			return failed
+		}
+
+		return nil
+	})
+
+	assert.ErrorIs(t, err, failed)
+
+	err = lookup.Walk(1, func(path lookup.Path, v reflect.Value, field *reflect.StructField) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, lookup.ErrNotStruct)
+}
+
+func TestPath(t *testing.T) {
+	p := lookup.Path("").Field("Map").Key("a").Index(1).Key(1.5).Field("Name")
+	assert.Equal(t, lookup.Path(`Map["a"][1][1.5].Name`), p)
+
+	assert.Equal(t, lookup.Path("M[`'\"`]"), lookup.Path("M").Key(`'"`))
+	assert.Equal(t, lookup.Path(`M[\"a"b'c`+"`"+`\"]`), lookup.Path("M").Key(`a"b'c`+"`"))
+
+	type obj struct {
+		M map[string]int
+	}
+
+	o := &obj{}
+
+	for _, key := range []string{"Key", "key", `'a'`, `a"b'c` + "`", `"'` + "`.x", `x"'` + "`" + `\`} {
+		path := string(lookup.Path("M").Key(key))
+
+		_, err := lookup.Set(o, path, 1)
+		require.NoError(t, err, path)
+		assert.Contains(t, o.M, key, path)
+
+		v, err := lookup.Get(o, path)
+		require.NoError(t, err, path)
+		assert.Equal(t, 1, v, path)
+	}
+
+	assert.Len(t, o.M, 6)
+}